@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	iface := flag.String("i", "", "show only entries of the given interface")
+	flag.Parse()
+
+	list, err := proc.Neighbours()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%-40s %-8s %-20s %-6s %-8s %s", "Address", "HWtype", "HWaddress", "Flags", "Mask", "Iface")
+	fmt.Println()
+	for _, n := range list {
+		if *iface != "" && n.Device != *iface {
+			continue
+		}
+		var (
+			hwtype = hardwareType(n.HwType)
+			hwaddr = n.HardwareAddr()
+		)
+		if !n.Complete() {
+			hwtype, hwaddr = "", "(incomplete)"
+		}
+		fmt.Printf("%-40s %-8s %-20s %-6s %-8s %s", n.Addr, hwtype, hwaddr, n.Flags, n.Mask, n.Device)
+		fmt.Println()
+	}
+}
+
+func hardwareType(typ int) string {
+	switch typ {
+	case 1:
+		return "ether"
+	case 24:
+		return "ieee1394"
+	case 32:
+		return "infiniband"
+	case 768:
+		return "tunnel"
+	case 772:
+		return "loop"
+	default:
+		return strconv.Itoa(typ)
+	}
+}
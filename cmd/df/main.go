@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		human  = flag.Bool("h", false, "print sizes in human readable format")
+		inodes = flag.Bool("i", false, "show inodes information instead of blocks")
+		types  = flag.String("t", "", "show only filesystems of the given types (comma separated)")
+		all    = flag.Bool("a", false, "include pseudo and empty filesystems")
+	)
+	flag.Parse()
+
+	list, err := proc.Filesystems()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	keep := make(map[string]bool)
+	if *types != "" {
+		for _, t := range strings.Split(*types, ",") {
+			keep[t] = true
+		}
+	}
+	size := func(n int64) string {
+		if *human {
+			return humanize(n)
+		}
+		return strconv.FormatInt(n/1024, 10)
+	}
+
+	if *inodes {
+		fmt.Printf("%-24s %-10s %12s %12s %12s %5s %s", "Filesystem", "Type", "Inodes", "IUsed", "IFree", "IUse%", "Mounted on")
+	} else {
+		unit := "1K-blocks"
+		if *human {
+			unit = "Size"
+		}
+		fmt.Printf("%-24s %-10s %12s %12s %12s %5s %s", "Filesystem", "Type", unit, "Used", "Avail", "Use%", "Mounted on")
+	}
+	fmt.Println()
+	for _, f := range list {
+		if len(keep) > 0 && !keep[f.Type] {
+			continue
+		}
+		if !*all && len(keep) == 0 && (f.Pseudo() || f.Size == 0) {
+			continue
+		}
+		if *inodes {
+			fmt.Printf("%-24s %-10s %12d %12d %12d %4.0f%% %s", f.Source, f.Type, f.Files, f.FilesUsed(), f.FilesFree, f.FilesPercent(), f.Point)
+		} else {
+			fmt.Printf("%-24s %-10s %12s %12s %12s %4.0f%% %s", f.Source, f.Type, size(f.Size), size(f.Used()), size(f.Avail), f.UsedPercent(), f.Point)
+		}
+		fmt.Println()
+	}
+}
+
+func humanize(n int64) string {
+	const units = "KMGTPE"
+	if n < 1024 {
+		return strconv.FormatInt(n, 10)
+	}
+	var (
+		val = float64(n)
+		ix  = -1
+	)
+	for val >= 1024 && ix < len(units)-1 {
+		val /= 1024
+		ix++
+	}
+	if val < 10 {
+		return fmt.Sprintf("%.1f%c", val, units[ix])
+	}
+	return fmt.Sprintf("%.0f%c", val, units[ix])
+}
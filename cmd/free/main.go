@@ -1,18 +1,53 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/midbel/symon/proc"
 )
 
 func main() {
+	human := flag.Bool("h", false, "print sizes in human readable format")
+	flag.Parse()
+
 	mem, swap, err := proc.Free()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	fmt.Println(mem)
-	fmt.Println(swap)
+	size := func(n int64) string {
+		if *human {
+			return humanize(n)
+		}
+		return strconv.FormatInt(n, 10)
+	}
+	fmt.Printf("%-6s %12s %12s %12s %12s %12s %12s", "", "total", "used", "free", "shared", "buff/cache", "available")
+	fmt.Println()
+	fmt.Printf("%-6s %12s %12s %12s %12s %12s %12s", "Mem:", size(mem.Total), size(mem.Used()), size(mem.Free), size(mem.Shared), size(mem.BuffCache()), size(mem.Available))
+	fmt.Println()
+	fmt.Printf("%-6s %12s %12s %12s", "Swap:", size(swap.Total), size(swap.Used()), size(swap.Free))
+	fmt.Println()
+}
+
+// humanize formats a size given in kB.
+func humanize(n int64) string {
+	const units = "MGTPE"
+	if n < 1024 {
+		return strconv.FormatInt(n, 10) + "K"
+	}
+	var (
+		val = float64(n)
+		ix  = -1
+	)
+	for val >= 1024 && ix < len(units)-1 {
+		val /= 1024
+		ix++
+	}
+	if val < 10 {
+		return fmt.Sprintf("%.1f%c", val, units[ix])
+	}
+	return fmt.Sprintf("%.0f%c", val, units[ix])
 }
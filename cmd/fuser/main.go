@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		mount   = flag.Bool("m", false, "name is a mount point: list processes using files on its filesystem")
+		space   = flag.String("n", "file", "name space: file, tcp or udp")
+		verbose = flag.Bool("v", false, "verbose mode")
+		code    = 1
+	)
+	flag.Parse()
+
+	for _, a := range flag.Args() {
+		list, err := find(a, *space, *mount)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s", a, err)
+			fmt.Fprintln(os.Stderr)
+			continue
+		}
+		if len(list) > 0 {
+			code = 0
+		}
+		if *verbose {
+			printVerbose(a, list)
+		} else {
+			printPids(a, list)
+		}
+	}
+	os.Exit(code)
+}
+
+func find(name, space string, mount bool) ([]proc.FileUser, error) {
+	if str, proto, ok := strings.Cut(name, "/"); ok && (proto == "tcp" || proto == "udp") {
+		name, space = str, proto
+	}
+	switch space {
+	case "tcp", "udp":
+		port, err := strconv.ParseUint(name, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port")
+		}
+		return proc.FilesByPort(space, uint16(port))
+	case "file":
+		if mount {
+			return proc.FilesByMount(name)
+		}
+		return proc.FilesByPath(name)
+	default:
+		return nil, fmt.Errorf("%s: unsupported name space", space)
+	}
+}
+
+func printPids(name string, list []proc.FileUser) {
+	fmt.Printf("%s:", name)
+	for _, pid := range uniquePids(list) {
+		fmt.Printf(" %d", pid)
+	}
+	fmt.Println()
+}
+
+func printVerbose(name string, list []proc.FileUser) {
+	fmt.Printf("%-20s %-12s %-8s %-6s %s", "name", "user", "pid", "access", "command")
+	fmt.Println()
+	seen := make(map[int]bool)
+	for _, f := range list {
+		if seen[f.Pid] {
+			continue
+		}
+		seen[f.Pid] = true
+		fmt.Printf("%-20s %-12s %-8d %-6s %s", name, f.User, f.Pid, f.Mode(), f.Command)
+		fmt.Println()
+	}
+}
+
+func uniquePids(list []proc.FileUser) []int {
+	var (
+		pids []int
+		seen = make(map[int]bool)
+	)
+	for _, f := range list {
+		if seen[f.Pid] {
+			continue
+		}
+		seen[f.Pid] = true
+		pids = append(pids, f.Pid)
+	}
+	sort.Ints(pids)
+	return pids
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		iface = flag.String("i", "", "show only the given interface")
+		every = flag.Duration("d", time.Second, "refresh interval")
+		count = flag.Int("n", 0, "number of reports (0 for infinite)")
+	)
+	flag.Parse()
+
+	prev, err := proc.Interfaces()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	tick := time.NewTicker(*every)
+	defer tick.Stop()
+
+	for i := 0; *count <= 0 || i < *count; i++ {
+		<-tick.C
+		curr, err := proc.Interfaces()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%-12s %12s %12s %10s %10s %8s %8s", "interface", "rx kB/s", "tx kB/s", "rx pkt/s", "tx pkt/s", "errs/s", "drop/s")
+		fmt.Println()
+		for _, r := range proc.InterfaceRates(prev, curr) {
+			if *iface != "" && r.Name != *iface {
+				continue
+			}
+			fmt.Printf("%-12s %12.2f %12.2f %10.1f %10.1f %8.1f %8.1f", r.Name, r.RxBytes/1024, r.TxBytes/1024, r.RxPackets, r.TxPackets, r.RxErrors+r.TxErrors, r.RxDrops+r.TxDrops)
+			fmt.Println()
+		}
+		fmt.Println()
+		prev = curr
+	}
+}
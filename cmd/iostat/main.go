@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		every = flag.Duration("d", time.Second, "refresh interval")
+		count = flag.Int("n", 1, "number of reports (0 for infinite)")
+		all   = flag.Bool("a", false, "include devices without activity")
+	)
+	flag.Parse()
+
+	devices := make(map[string]bool)
+	for _, a := range flag.Args() {
+		devices[a] = true
+	}
+
+	curr, err := proc.Disks()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// the first report shows the activity since the system boot
+	boot, err := proc.BootTime()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	prev := make([]proc.DiskInfo, 0, len(curr))
+	for _, d := range curr {
+		prev = append(prev, proc.DiskInfo{
+			Name: d.Name,
+			When: boot,
+		})
+	}
+
+	tick := time.NewTicker(*every)
+	defer tick.Stop()
+
+	for i := 0; ; i++ {
+		report(proc.DiskRates(prev, curr), devices, *all)
+		if *count > 0 && i+1 >= *count {
+			break
+		}
+		<-tick.C
+		prev = curr
+		if curr, err = proc.Disks(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func report(list []proc.DiskActivity, devices map[string]bool, all bool) {
+	fmt.Printf("%-12s %8s %8s %10s %10s %8s %8s %8s %8s %8s %6s", "device", "r/s", "w/s", "rkB/s", "wkB/s", "rrqm/s", "wrqm/s", "r_await", "w_await", "aqu-sz", "%util")
+	fmt.Println()
+	for _, d := range list {
+		if len(devices) > 0 && !devices[d.Name] {
+			continue
+		}
+		if len(devices) == 0 && !all && d.Reads == 0 && d.Writes == 0 {
+			continue
+		}
+		fmt.Printf("%-12s %8.2f %8.2f %10.2f %10.2f %8.2f %8.2f %8.2f %8.2f %8.2f %6.2f", d.Name, d.Reads, d.Writes, d.ReadBytes/1024, d.WriteBytes/1024, d.ReadsMerged, d.WritesMerged, milliseconds(d.ReadAwait), milliseconds(d.WriteAwait), d.QueueSize, d.Util)
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
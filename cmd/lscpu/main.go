@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/slices"
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	extended := flag.Bool("e", false, "show one line per cpu")
+	flag.Parse()
+
+	topo, err := proc.Topology()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *extended {
+		printExtended(topo)
+		return
+	}
+	list, err := proc.Processors()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	printSummary(list, topo)
+}
+
+func printSummary(list []proc.ProcessorInfo, topo []proc.CpuTopology) {
+	var (
+		cpu      = slices.Fst(list)
+		online   []int
+		offline  []int
+		cores    = make(map[[2]int]struct{})
+		packages = make(map[int]struct{})
+		nodes    = make(map[int]struct{})
+		maxFreq  float64
+		minFreq  float64
+	)
+	for _, t := range topo {
+		if !t.Online {
+			offline = append(offline, t.Cpu)
+			continue
+		}
+		online = append(online, t.Cpu)
+		cores[[2]int{t.Package, t.Core}] = struct{}{}
+		packages[t.Package] = struct{}{}
+		nodes[t.Node] = struct{}{}
+		if t.MaxFreq > maxFreq {
+			maxFreq = t.MaxFreq
+		}
+		if minFreq == 0 || (t.MinFreq > 0 && t.MinFreq < minFreq) {
+			minFreq = t.MinFreq
+		}
+	}
+	show := func(label string, value interface{}) {
+		fmt.Printf("%-24s %v", label+":", value)
+		fmt.Println()
+	}
+	show("Architecture", runtime.GOARCH)
+	show("CPU(s)", len(topo))
+	show("On-line CPU(s) list", formatList(online))
+	if len(offline) > 0 {
+		show("Off-line CPU(s) list", formatList(offline))
+	}
+	show("Vendor ID", cpu.Vendor)
+	show("Model name", cpu.ModelName)
+	show("CPU family", cpu.Family)
+	show("Model", cpu.Model)
+	show("Stepping", cpu.Stepping)
+	if len(cores) > 0 && len(packages) > 0 {
+		show("Thread(s) per core", len(online)/len(cores))
+		show("Core(s) per socket", len(cores)/len(packages))
+		show("Socket(s)", len(packages))
+	}
+	show("NUMA node(s)", len(nodes))
+	show("CPU MHz", fmt.Sprintf("%.3f", cpu.MHz))
+	if maxFreq > 0 {
+		show("CPU max MHz", fmt.Sprintf("%.4f", maxFreq))
+		show("CPU min MHz", fmt.Sprintf("%.4f", minFreq))
+	}
+	show("BogoMIPS", fmt.Sprintf("%.2f", cpu.Bogomips))
+	if cpu.CacheSize > 0 {
+		show("Cache size", fmt.Sprintf("%d KB", cpu.CacheSize))
+	}
+	if cpu.Has("hypervisor") {
+		show("Hypervisor", "yes")
+	}
+	show("Flags", strings.Join(cpu.Flags, " "))
+}
+
+func printExtended(topo []proc.CpuTopology) {
+	fmt.Printf("%-4s %-5s %-7s %-5s %-7s %10s %10s %10s %s", "CPU", "NODE", "SOCKET", "CORE", "ONLINE", "MAXMHZ", "MINMHZ", "MHZ", "GOVERNOR")
+	fmt.Println()
+	for _, t := range topo {
+		online := "no"
+		if t.Online {
+			online = "yes"
+		}
+		fmt.Printf("%-4d %-5d %-7d %-5d %-7s %10.4f %10.4f %10.4f %s", t.Cpu, t.Node, t.Package, t.Core, online, t.MaxFreq, t.MinFreq, t.CurFreq, t.Governor)
+		fmt.Println()
+	}
+}
+
+func formatList(list []int) string {
+	var parts []string
+	for i := 0; i < len(list); {
+		j := i
+		for j+1 < len(list) && list[j+1] == list[j]+1 {
+			j++
+		}
+		part := strconv.Itoa(list[i])
+		if j > i {
+			part += "-" + strconv.Itoa(list[j])
+		}
+		parts = append(parts, part)
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
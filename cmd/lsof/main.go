@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		pid     = flag.Int("p", 0, "list files opened by process")
+		inet    = flag.String("i", "", "list sockets bound to [proto][:port]")
+		mount   = flag.Bool("m", false, "list files opened on the filesystem(s) mounted on given directories")
+		deleted = flag.Bool("L", false, "list only deleted files still opened")
+	)
+	flag.Parse()
+
+	list, err := collect(*pid, *inet, *mount)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Pid == list[j].Pid {
+			return list[i].Fd < list[j].Fd
+		}
+		return list[i].Pid < list[j].Pid
+	})
+
+	fmt.Printf("%-16s %-8s %-12s %-5s %-10s %-10s %-12s %s", "command", "pid", "user", "fd", "type", "node", "offset", "name")
+	fmt.Println()
+	for _, f := range list {
+		if *deleted && !f.Deleted {
+			continue
+		}
+		name := f.Target
+		if f.Deleted {
+			name += " (deleted)"
+		}
+		fmt.Printf("%-16s %-8d %-12s %-5s %-10s %-10d %-12d %s", f.Command, f.Pid, f.User, strconv.Itoa(f.Fd)+f.Mode(), f.Type, f.Inode, f.Pos, name)
+		fmt.Println()
+	}
+}
+
+func collect(pid int, inet string, mount bool) ([]proc.FileUser, error) {
+	switch {
+	case pid > 0:
+		return proc.FilesByPid(pid)
+	case inet != "":
+		proto, str, _ := strings.Cut(inet, ":")
+		if str == "" {
+			return proc.FilesByProto(proto)
+		}
+		port, err := strconv.ParseUint(str, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid port", str)
+		}
+		return proc.FilesByPort(proto, uint16(port))
+	case flag.NArg() > 0:
+		var list []proc.FileUser
+		for _, a := range flag.Args() {
+			var (
+				res []proc.FileUser
+				err error
+			)
+			if mount {
+				res, err = proc.FilesByMount(a)
+			} else {
+				res, err = proc.FilesByPath(a)
+			}
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, res...)
+		}
+		return list, nil
+	default:
+		return proc.OpenFiles()
+	}
+}
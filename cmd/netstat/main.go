@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,12 +9,124 @@ import (
 )
 
 func main() {
-	conns, err := proc.Netstat()
+	var (
+		owner   = flag.Bool("p", false, "show pid and name of the program owning the socket")
+		timers  = flag.Bool("o", false, "show timers of the socket")
+		tcp     = flag.Bool("tcp", false, "show tcp sockets")
+		udp     = flag.Bool("udp", false, "show udp sockets")
+		udplite = flag.Bool("udplite", false, "show udplite sockets")
+		raw     = flag.Bool("raw", false, "show raw sockets")
+		icmp    = flag.Bool("icmp", false, "show icmp sockets")
+		unix    = flag.Bool("unix", false, "show unix domain sockets")
+	)
+	flag.Parse()
+
+	if !*tcp && !*udp && !*udplite && !*raw && !*icmp && !*unix {
+		*tcp, *udp = true, true
+	}
+
+	var (
+		conns []proc.ConnInfo
+		sets  = []struct {
+			Enabled bool
+			Read    func() ([]proc.ConnInfo, error)
+		}{
+			{Enabled: *tcp, Read: proc.Tcp},
+			{Enabled: *udp, Read: proc.Udp},
+			{Enabled: *udplite, Read: proc.UdpLite},
+			{Enabled: *raw, Read: proc.Raw},
+			{Enabled: *icmp, Read: proc.Icmp},
+		}
+	)
+	for _, s := range sets {
+		if !s.Enabled {
+			continue
+		}
+		list, err := s.Read()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		conns = append(conns, list...)
+	}
+	var index *proc.SocketIndex
+	if *owner {
+		x, err := proc.Sockets()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		index = &x
+	}
+	if len(conns) > 0 {
+		printConns(conns, index, *timers)
+	}
+	if *unix {
+		if len(conns) > 0 {
+			fmt.Println()
+		}
+		printUnix(index)
+	}
+}
+
+func printConns(conns []proc.ConnInfo, index *proc.SocketIndex, timers bool) {
+	owner := index != nil
+	if owner {
+		index.SetOwners(conns)
+	}
+	fmt.Printf("%-8s %-8s %-8s %-28s %-28s %-12s %-12s", "proto", "recv-q", "send-q", "local address", "foreign address", "state", "user")
+	if owner {
+		fmt.Printf(" %-24s", "pid/program name")
+	}
+	if timers {
+		fmt.Printf(" %s", "timer")
+	}
+	fmt.Println()
+	for _, c := range conns {
+		fmt.Printf("%-8s %-8d %-8d %-28s %-28s %-12s %-12s", c.Proto, c.RxQueue, c.TxQueue, c.Local, c.Remote, c.State, c.User)
+		if owner {
+			fmt.Printf(" %-24s", program(c.Pid, c.Command))
+		}
+		if timers {
+			fmt.Printf(" %s (%.2f/%d/%d)", c.Timer, c.Expires.Seconds(), c.Retransmits, c.Timeout)
+		}
+		fmt.Println()
+	}
+}
+
+func printUnix(index *proc.SocketIndex) {
+	list, err := proc.Unix()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	for _, c := range conns {
-		fmt.Println(c.Proto, c.State, c.User, c.Local, c.Remote)
+	owner := index != nil
+	if owner {
+		index.SetUnixOwners(list)
+	}
+	fmt.Printf("%-6s %-4s %-10s %-14s %-10s", "proto", "refs", "type", "state", "inode")
+	if owner {
+		fmt.Printf(" %-24s", "pid/program name")
+	}
+	fmt.Printf(" %s", "path")
+	fmt.Println()
+	for _, u := range list {
+		state := u.State.String()
+		if u.Listen {
+			state = "LISTENING"
+		}
+		fmt.Printf("%-6s %-4d %-10s %-14s %-10d", "unix", u.RefCount, u.Type, state, u.Inode)
+		if owner {
+			fmt.Printf(" %-24s", program(u.Pid, u.Command))
+		}
+		fmt.Printf(" %s", u.Path)
+		fmt.Println()
+	}
+}
+
+func program(pid int, cmd string) string {
+	if pid == 0 {
+		return "-"
 	}
+	return fmt.Sprintf("%d/%s", pid, cmd)
 }
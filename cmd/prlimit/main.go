@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		pid = flag.Int("p", os.Getpid(), "process id")
+		all = flag.Bool("n", false, "compare open files with their limit for all processes")
+	)
+	flag.Parse()
+
+	if *all {
+		if err := printFiles(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	detail, err := proc.Detail(*pid)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%-12s %-24s %12s %12s %s", "resource", "description", "soft", "hard", "units")
+	fmt.Println()
+	for _, i := range detail.Limits {
+		fmt.Printf("%-12s %-24s %12s %12s %s", i.Resource, i.Desc, formatLimit(i.Soft), formatLimit(i.Hard), i.Unit)
+		fmt.Println()
+	}
+	fmt.Println()
+	files := "-"
+	if detail.Fds >= 0 {
+		files = strconv.Itoa(detail.Fds)
+	}
+	fmt.Printf("open files: %s, oom score: %d (adj: %d), policy: %s (rt priority: %d), affinity: %v, ctxt switches: %d/%d", files, detail.OomScore, detail.OomScoreAdj, detail.Policy, detail.RtPriority, detail.Affinity, detail.VoluntaryCtxt, detail.NonVoluntaryCtxt)
+	fmt.Println()
+}
+
+type usage struct {
+	proc.ProcDetail
+	Limit int64
+}
+
+func (u usage) Known() bool {
+	return u.Fds >= 0
+}
+
+func (u usage) Percent() float64 {
+	if u.Limit <= 0 || !u.Known() {
+		return 0
+	}
+	return float64(u.Fds) * 100 / float64(u.Limit)
+}
+
+func printFiles() error {
+	list, err := proc.Process()
+	if err != nil {
+		return err
+	}
+	var res []usage
+	for _, p := range list {
+		detail, err := proc.Detail(p.Pid)
+		if err != nil {
+			continue
+		}
+		u := usage{
+			ProcDetail: detail,
+			Limit:      proc.Unlimited,
+		}
+		if limit, ok := proc.Limit(detail.Limits, "nofile"); ok {
+			u.Limit = limit.Soft
+		}
+		res = append(res, u)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Known() != res[j].Known() {
+			return res[i].Known()
+		}
+		return res[i].Percent() > res[j].Percent()
+	})
+	fmt.Printf("%-8s %-12s %8s %12s %6s %5s %s", "pid", "user", "files", "limit", "%used", "oom", "command")
+	fmt.Println()
+	for _, u := range res {
+		files, percent := "-", "-"
+		if u.Known() {
+			files = strconv.Itoa(u.Fds)
+			percent = strconv.FormatFloat(u.Percent(), 'f', 1, 64)
+		}
+		fmt.Printf("%-8d %-12s %8s %12s %6s %5d %s", u.Pid, u.User, files, formatLimit(u.Limit), percent, u.OomScore, u.Cmd)
+		fmt.Println()
+	}
+	return nil
+}
+
+func formatLimit(limit int64) string {
+	if limit == proc.Unlimited {
+		return "unlimited"
+	}
+	return strconv.FormatInt(limit, 10)
+}
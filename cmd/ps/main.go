@@ -1,14 +1,23 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/midbel/symon/proc"
 )
 
 func main() {
+	var (
+		long   = flag.Bool("l", false, "show resources used by process(es)")
+		forest = flag.Bool("forest", false, "show process(es) hierarchy")
+	)
+	flag.Parse()
+
 	list, err := proc.Process()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -17,8 +26,66 @@ func main() {
 	sort.Slice(list, func(i, j int) bool {
 		return list[i].Pid < list[j].Pid
 	})
+	if *forest {
+		list = makeForest(list)
+	}
+	if *long {
+		printLong(list)
+		return
+	}
 	for _, i := range list {
 		fmt.Printf("%-8d %-16s %-16s %-4c %s", i.Pid, i.User, i.Group, i.Status, i.Cmd)
 		fmt.Println()
 	}
 }
+
+func printLong(list []proc.ProcInfo) {
+	fmt.Printf("%-8s %-8s %-12s %-4s %4s %4s %5s %10s %10s %-8s %-5s %8s %4s %-12s %s", "pid", "ppid", "user", "stat", "pri", "ni", "%cpu", "vsz", "rss", "tty", "start", "time", "nlwp", "container", "command")
+	fmt.Println()
+	for _, i := range list {
+		tty := i.Terminal()
+		if tty == "" {
+			tty = "?"
+		}
+		container := "-"
+		if i.Container != "" {
+			container = i.Container[:12]
+		}
+		fmt.Printf("%-8d %-8d %-12s %-4c %4d %4d %5.1f %10d %10d %-8s %-5s %8s %4d %-12s %s", i.Pid, i.PPid, i.User, i.Status, i.Priority, i.Nice, proc.ProcessUsage(proc.ProcInfo{}, i), i.Vsz>>10, i.Rss>>10, tty, formatStart(i.Start), formatTime(i.CpuTime()), i.Threads, container, i.Cmd)
+		fmt.Println()
+	}
+}
+
+func formatStart(when time.Time) string {
+	now := time.Now()
+	if now.Sub(when) < time.Hour*24 {
+		return when.Format("15:04")
+	}
+	return when.Format("Jan02")
+}
+
+func formatTime(elapsed time.Duration) string {
+	var (
+		hours = elapsed / time.Hour
+		mins  = (elapsed % time.Hour) / time.Minute
+		secs  = (elapsed % time.Minute) / time.Second
+	)
+	return fmt.Sprintf("%02d:%02d:%02d", hours, mins, secs)
+}
+
+// makeForest reorders the processes to follow their hierarchy and prefixes
+// the command of each process according to its depth in the tree.
+func makeForest(list []proc.ProcInfo) []proc.ProcInfo {
+	var (
+		tree = proc.NewProcessTree(list)
+		res  = make([]proc.ProcInfo, 0, len(list))
+	)
+	tree.Walk(func(n *proc.ProcNode, depth int) {
+		ifo := n.ProcInfo
+		if depth > 0 {
+			ifo.Cmd = strings.Repeat("    ", depth-1) + " \\_ " + ifo.Cmd
+		}
+		res = append(res, ifo)
+	})
+	return res
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/symon/proc"
+)
+
+type connectors struct {
+	Branch string
+	Last   string
+	Line   string
+	Space  string
+}
+
+var (
+	unicode = connectors{
+		Branch: "├─",
+		Last:   "└─",
+		Line:   "│ ",
+		Space:  "  ",
+	}
+	ascii = connectors{
+		Branch: "|-",
+		Last:   "`-",
+		Line:   "| ",
+		Space:  "  ",
+	}
+)
+
+type printer struct {
+	connectors
+	Pids    bool
+	Compact bool
+}
+
+func main() {
+	var (
+		pr  printer
+		asc = flag.Bool("a", false, "use ascii characters to draw the tree")
+	)
+	flag.BoolVar(&pr.Pids, "p", false, "show pid of process(es)")
+	flag.BoolVar(&pr.Compact, "c", false, "collapse identical siblings")
+	flag.Parse()
+
+	pr.connectors = unicode
+	if *asc {
+		pr.connectors = ascii
+	}
+
+	list, err := proc.Process()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	tree := proc.NewProcessTree(list)
+
+	roots := tree.Roots
+	if flag.NArg() > 0 {
+		pid, err := strconv.Atoi(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: invalid pid", flag.Arg(0))
+			fmt.Fprintln(os.Stderr)
+			os.Exit(2)
+		}
+		n, ok := tree.Get(pid)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "%d: process not found", pid)
+			fmt.Fprintln(os.Stderr)
+			os.Exit(1)
+		}
+		roots = []*proc.ProcNode{n}
+	}
+	for _, n := range roots {
+		pr.Print(n)
+	}
+}
+
+func (p printer) Print(n *proc.ProcNode) {
+	fmt.Println(p.label(n, 1))
+	p.printChildren(n, "")
+}
+
+func (p printer) printChildren(n *proc.ProcNode, prefix string) {
+	groups := p.group(n.Children)
+	for i, g := range groups {
+		var (
+			last   = i == len(groups)-1
+			branch = p.Branch
+			indent = p.Line
+		)
+		if last {
+			branch, indent = p.Last, p.Space
+		}
+		fmt.Println(prefix + branch + p.label(g[0], len(g)))
+		p.printChildren(g[0], prefix+indent)
+	}
+}
+
+func (p printer) label(n *proc.ProcNode, count int) string {
+	str := n.Cmd
+	if p.Pids {
+		str = fmt.Sprintf("%s(%d)", str, n.Pid)
+	}
+	if count > 1 {
+		str = fmt.Sprintf("%d*[%s]", count, str)
+	}
+	return str
+}
+
+// group gathers the siblings having the same command and the same sub tree.
+// Each process is in its own group when compaction is disabled or when pids
+// are shown.
+func (p printer) group(list []*proc.ProcNode) [][]*proc.ProcNode {
+	var groups [][]*proc.ProcNode
+	if !p.Compact || p.Pids {
+		for _, n := range list {
+			groups = append(groups, []*proc.ProcNode{n})
+		}
+		return groups
+	}
+	seen := make(map[string]int)
+	for _, n := range list {
+		sig := signature(n)
+		if i, ok := seen[sig]; ok {
+			groups[i] = append(groups[i], n)
+			continue
+		}
+		seen[sig] = len(groups)
+		groups = append(groups, []*proc.ProcNode{n})
+	}
+	return groups
+}
+
+func signature(n *proc.ProcNode) string {
+	var list []string
+	for _, c := range n.Children {
+		list = append(list, signature(c))
+	}
+	sort.Strings(list)
+	return n.Cmd + "(" + strings.Join(list, ",") + ")"
+}
@@ -1,19 +1,60 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"net"
+	"net/netip"
 	"os"
 
 	"github.com/midbel/symon/proc"
 )
 
 func main() {
-	routes, err := proc.Routes()
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	var (
+		only4 = flag.Bool("4", false, "show only IPv4 routes")
+		only6 = flag.Bool("6", false, "show only IPv6 routes")
+	)
+	flag.Parse()
+
+	if !*only6 {
+		routes, err := proc.Routes()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		printRoutes(routes)
+	}
+	if !*only4 {
+		routes, err := proc.Routes6()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !*only6 {
+			fmt.Println()
+		}
+		printRoutes6(routes)
+	}
+}
+
+func printRoutes(routes []proc.RouteInfo) {
+	fmt.Println("Kernel IP routing table")
+	fmt.Printf("%-16s %-16s %-16s %-6s %-6s %-6s %8s %s", "Destination", "Gateway", "Genmask", "Flags", "Metric", "Ref", "Use", "Iface")
+	fmt.Println()
+	for _, r := range routes {
+		mask, _ := netip.AddrFromSlice(net.CIDRMask(r.Mask.Bits(), 32))
+		fmt.Printf("%-16s %-16s %-16s %-6s %-6d %-6d %8d %s", r.Network, r.Gateway, mask, r.Flags, r.Metric, r.RefCnt, r.Use, r.Interface)
+		fmt.Println()
 	}
+}
+
+func printRoutes6(routes []proc.RouteInfo) {
+	fmt.Println("Kernel IPv6 routing table")
+	fmt.Printf("%-40s %-40s %-6s %-10s %-6s %8s %s", "Destination", "Next Hop", "Flag", "Met", "Ref", "Use", "If")
+	fmt.Println()
 	for _, r := range routes {
-		fmt.Println(r.Interface, r.Network, r.Gateway)
+		fmt.Printf("%-40s %-40s %-6s %-10d %-6d %8d %s", r.Mask, r.Gateway, r.Flags, r.Metric, r.RefCnt, r.Use, r.Interface)
+		fmt.Println()
 	}
 }
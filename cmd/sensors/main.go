@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	root := flag.String("r", "/", "root directory of the system")
+	flag.Parse()
+
+	list, err := proc.NewSource(*root).Sensors()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var device string
+	for _, s := range list {
+		if s.Device != device {
+			if device != "" {
+				fmt.Println()
+			}
+			device = s.Device
+			fmt.Printf("%s-%s", s.Chip, s.Device)
+			fmt.Println()
+		}
+		fmt.Printf("%-16s %s", s.Label+":", format(s.Input, s.Kind))
+		var limits []string
+		if !math.IsNaN(s.Min) {
+			limits = append(limits, "min = "+format(s.Min, s.Kind))
+		}
+		if !math.IsNaN(s.Max) {
+			limits = append(limits, "max = "+format(s.Max, s.Kind))
+		}
+		if !math.IsNaN(s.Crit) {
+			limits = append(limits, "crit = "+format(s.Crit, s.Kind))
+		}
+		if len(limits) > 0 {
+			fmt.Printf("  (%s)", strings.Join(limits, ", "))
+		}
+		fmt.Println()
+	}
+}
+
+func format(value float64, kind proc.SensorKind) string {
+	switch kind {
+	case proc.SensorTemp:
+		return fmt.Sprintf("%+.1f%s", value, kind.Unit())
+	case proc.SensorFan:
+		return fmt.Sprintf("%.0f %s", value, kind.Unit())
+	default:
+		return fmt.Sprintf("%.2f %s", value, kind.Unit())
+	}
+}
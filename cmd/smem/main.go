@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/midbel/symon/proc"
+)
+
+type entry struct {
+	Name  string
+	Count int
+	proc.MemUsage
+}
+
+func (e *entry) Add(u proc.MemUsage) {
+	e.Count++
+	e.Rss += u.Rss
+	e.Pss += u.Pss
+	e.SharedClean += u.SharedClean
+	e.SharedDirty += u.SharedDirty
+	e.PrivateClean += u.PrivateClean
+	e.PrivateDirty += u.PrivateDirty
+	e.Swap += u.Swap
+	e.SwapPss += u.SwapPss
+}
+
+func main() {
+	var (
+		byUser = flag.Bool("u", false, "aggregate memory by user")
+		byCmd  = flag.Bool("c", false, "aggregate memory by command name")
+		human  = flag.Bool("k", false, "print sizes in human readable format")
+		maps   = flag.Int("m", 0, "show the mappings of the given process")
+	)
+	flag.Parse()
+
+	var (
+		unit   = "(KiB)"
+		format = func(n int64) string {
+			if *human {
+				return humanize(n)
+			}
+			return strconv.FormatInt(n>>10, 10)
+		}
+	)
+	if *human {
+		unit = ""
+	}
+	if *maps > 0 {
+		if err := printMaps(*maps, unit, format); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	list, err := proc.Process()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var (
+		index = make(map[string]*entry)
+		res   []*entry
+		label = "pid"
+	)
+	switch {
+	case *byUser:
+		label = "user"
+	case *byCmd:
+		label = "command"
+	}
+	for _, p := range list {
+		usage, err := proc.MemoryUsage(p.Pid)
+		if err != nil || usage.Rss == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%-8d %-12s %s", p.Pid, p.User, p.Cmd)
+		switch {
+		case *byUser:
+			key = p.User
+		case *byCmd:
+			key = p.Cmd
+		}
+		e, ok := index[key]
+		if !ok {
+			e = &entry{Name: key}
+			index[key] = e
+			res = append(res, e)
+		}
+		e.Add(usage)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Pss > res[j].Pss
+	})
+	fmt.Printf("%10s %10s %10s %10s", "swap"+unit, "uss"+unit, "pss"+unit, "rss"+unit)
+	if label == "pid" {
+		fmt.Printf(" %-8s %-12s %s", "pid", "user", "command")
+	} else {
+		fmt.Printf(" %6s %s", "count", label)
+	}
+	fmt.Println()
+	var total entry
+	for _, e := range res {
+		if label == "pid" {
+			fmt.Printf("%10s %10s %10s %10s %s", format(e.Swap), format(e.Uss()), format(e.Pss), format(e.Rss), e.Name)
+		} else {
+			fmt.Printf("%10s %10s %10s %10s %6d %s", format(e.Swap), format(e.Uss()), format(e.Pss), format(e.Rss), e.Count, e.Name)
+		}
+		fmt.Println()
+		total.Add(e.MemUsage)
+	}
+	fmt.Printf("%10s %10s %10s %10s total", format(total.Swap), format(total.Uss()), format(total.Pss), format(total.Rss))
+	fmt.Println()
+}
+
+func printMaps(pid int, unit string, format func(int64) string) error {
+	list, err := proc.Maps(pid)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%10s %6s %s", "size"+unit, "count", "mapping")
+	fmt.Println()
+	for _, m := range proc.SummarizeMaps(list) {
+		fmt.Printf("%10s %6d %s", format(m.Size), m.Count, m.Path)
+		fmt.Println()
+	}
+	return nil
+}
+
+// humanize formats a size in bytes with a binary suffix: B, K, M, G, ...
+func humanize(n int64) string {
+	const units = "KMGTPE"
+	if n < 1024 {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+	var (
+		val = float64(n)
+		ix  = -1
+	)
+	for val >= 1024 && ix < len(units)-1 {
+		val /= 1024
+		ix++
+	}
+	if val < 10 {
+		return fmt.Sprintf("%.1f%c", val, units[ix])
+	}
+	return fmt.Sprintf("%.0f%c", val, units[ix])
+}
@@ -8,21 +8,44 @@ import (
 )
 
 type Collector struct {
+	src     proc.Source
 	lastmod time.Time
 
 	mu       sync.RWMutex
 	boottime time.Time
 	uptime   time.Duration
 	process  []proc.ProcInfo
+	procload map[int]float64
 	loadavg  []float64
 	swap     proc.MemInfo
 	syst     proc.MemInfo
 	users    []proc.Who
 	conns    []proc.ConnInfo
+	unix     []proc.UnixInfo
+	cpus     []proc.CpuInfo
+	usage    []proc.CpuUsage
+	kernel   proc.KernelStat
+	rates    proc.KernelRate
+	ifaces   []proc.IfaceInfo
+	traffic  []proc.IfaceRate
+	neigh    []proc.NeighInfo
+	disks    []proc.DiskInfo
+	activity []proc.DiskActivity
+	fs       []proc.FsInfo
+	pressure []proc.PressureInfo
+	cgroups  []proc.CgroupInfo
+	cgrates  []proc.CgroupActivity
+	vmstat   proc.VmStat
+	paging   proc.VmActivity
+	sensors  []proc.SensorInfo
+	models   []proc.ProcessorInfo
+	topology []proc.CpuTopology
+	identity proc.SystemInfo
 }
 
-func Monitor() *Collector {
+func Monitor(src proc.Source) *Collector {
 	return &Collector{
+		src:     src,
 		lastmod: time.Now(),
 	}
 }
@@ -46,6 +69,19 @@ func (c *Collector) About() map[string]interface{} {
 		"users":       len(c.users),
 		"process":     len(c.process),
 		"connections": len(c.conns),
+		"system": map[string]interface{}{
+			"hostname":     c.identity.Hostname,
+			"domainname":   c.identity.Domainname,
+			"machine-id":   c.identity.MachineId,
+			"kernel":       c.identity.Release,
+			"version":      c.identity.Version,
+			"os":           c.identity.PrettyName(),
+			"container":    c.identity.Container,
+			"container-id": c.identity.ContainerId,
+			"virtual":      c.identity.Virtual,
+			"boottime":     c.boottime,
+			"uptime":       c.uptime.Seconds(),
+		},
 	}
 }
 
@@ -55,16 +91,28 @@ func (c *Collector) Conns() []proc.ConnInfo {
 	return c.conns
 }
 
+func (c *Collector) Unix() []proc.UnixInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.unix
+}
+
 func (c *Collector) Users() []proc.Who {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.users
 }
 
-func (c *Collector) Process() []proc.ProcInfo {
+func (c *Collector) Process() ([]proc.ProcInfo, map[int]float64) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.process
+	return c.process, c.procload
+}
+
+// Detail reads the details of a process directly from the system since they
+// are not collected periodically.
+func (c *Collector) Detail(pid int) (proc.ProcDetail, error) {
+	return c.src.Detail(pid)
 }
 
 func (c *Collector) Free() (proc.MemInfo, proc.MemInfo) {
@@ -79,36 +127,273 @@ func (c *Collector) LoadAvg() []float64 {
 	return c.loadavg
 }
 
-func (c *Collector) collect() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *Collector) Cpu() []proc.CpuUsage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usage
+}
 
-	var wg sync.WaitGroup
-	go collect(&wg, func() {
-		c.process, _ = proc.Process()
+func (c *Collector) Kernel() (proc.KernelStat, proc.KernelRate) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.kernel, c.rates
+}
+
+func (c *Collector) Interfaces() ([]proc.IfaceInfo, []proc.IfaceRate) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ifaces, c.traffic
+}
+
+func (c *Collector) Neighbours() []proc.NeighInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.neigh
+}
+
+func (c *Collector) Disks() []proc.DiskActivity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.activity
+}
+
+func (c *Collector) Filesystems() []proc.FsInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fs
+}
+
+func (c *Collector) Pressure() []proc.PressureInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pressure
+}
+
+func (c *Collector) Cgroups() ([]proc.CgroupInfo, []proc.CgroupActivity) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cgroups, c.cgrates
+}
+
+func (c *Collector) VirtualMemory() (proc.VmStat, proc.VmActivity) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.vmstat, c.paging
+}
+
+func (c *Collector) Sensors() []proc.SensorInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sensors
+}
+
+func (c *Collector) Processors() ([]proc.ProcessorInfo, []proc.CpuTopology) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.models, c.topology
+}
+
+// collect reads a new sample of all the metrics. The files are read
+// concurrently without holding the lock and the samples are swapped in, with
+// the rates computed from the previous ones, once all the readers are done.
+func (c *Collector) collect() {
+	var b batch
+	b.Go(func() func() {
+		list, _ := c.src.Process()
+		return func() {
+			prev := make(map[int]proc.ProcInfo)
+			for _, p := range c.process {
+				prev[p.Pid] = p
+			}
+			c.procload = make(map[int]float64)
+			for _, p := range list {
+				c.procload[p.Pid] = proc.ProcessUsage(prev[p.Pid], p)
+			}
+			c.process = list
+		}
+	})
+	b.Go(func() func() {
+		syst, swap, _ := c.src.Free()
+		return func() {
+			c.syst, c.swap = syst, swap
+		}
+	})
+	b.Go(func() func() {
+		users, _ := c.src.Current()
+		return func() {
+			c.users = users
+		}
 	})
-	go collect(&wg, func() {
-		c.syst, c.swap, _ = proc.Free()
+	b.Go(func() func() {
+		loadavg, _ := c.src.LoadAvg()
+		return func() {
+			c.loadavg = loadavg
+		}
 	})
-	go collect(&wg, func() {
-		c.users, _ = proc.Current()
+	b.Go(func() func() {
+		pressure, _ := c.src.Pressure()
+		return func() {
+			c.pressure = pressure
+		}
 	})
-	go collect(&wg, func() {
-		c.loadavg, _ = proc.LoadAvg()
+	b.Go(func() func() {
+		stat, err := c.src.VirtualMemory()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if !c.vmstat.When.IsZero() {
+				c.paging = proc.VmRate(c.vmstat, stat)
+			}
+			c.vmstat = stat
+		}
 	})
-	go collect(&wg, func() {
-		c.boottime, _ = proc.BootTime()
-		c.uptime, _ = proc.Uptime()
+	b.Go(func() func() {
+		list, err := c.src.Cgroups()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if c.cgroups != nil {
+				c.cgrates = proc.CgroupRates(c.cgroups, list)
+			}
+			c.cgroups = list
+		}
 	})
-	go collect(&wg, func() {
-		c.conns, _ = proc.Netstat()
+	b.Go(func() func() {
+		boottime, _ := c.src.BootTime()
+		uptime, _ := c.src.Uptime()
+		return func() {
+			c.boottime, c.uptime = boottime, uptime
+		}
 	})
+	b.Go(func() func() {
+		stat, err := c.src.Kernel()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if !c.kernel.When.IsZero() {
+				c.rates = proc.KernelRates(c.kernel, stat)
+			}
+			c.kernel = stat
+		}
+	})
+	b.Go(func() func() {
+		conns, _ := c.src.Netstat()
+		for _, get := range []func() ([]proc.ConnInfo, error){c.src.UdpLite, c.src.Raw, c.src.Icmp} {
+			list, _ := get()
+			conns = append(conns, list...)
+		}
+		unix, _ := c.src.Unix()
+		if index, err := c.src.Sockets(); err == nil {
+			index.SetOwners(conns)
+			index.SetUnixOwners(unix)
+		}
+		return func() {
+			c.conns, c.unix = conns, unix
+		}
+	})
+	b.Go(func() func() {
+		list, err := c.src.Interfaces()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if c.ifaces != nil {
+				c.traffic = proc.InterfaceRates(c.ifaces, list)
+			}
+			c.ifaces = list
+		}
+	})
+	b.Go(func() func() {
+		neigh, _ := c.src.Neighbours()
+		return func() {
+			c.neigh = neigh
+		}
+	})
+	b.Go(func() func() {
+		fs, _ := c.src.Filesystems()
+		return func() {
+			c.fs = fs
+		}
+	})
+	b.Go(func() func() {
+		sensors, _ := c.src.Sensors()
+		return func() {
+			c.sensors = sensors
+		}
+	})
+	b.Go(func() func() {
+		identity, _ := c.src.Identity()
+		return func() {
+			c.identity = identity
+		}
+	})
+	b.Go(func() func() {
+		models, _ := c.src.Processors()
+		topology, _ := c.src.Topology()
+		return func() {
+			c.models, c.topology = models, topology
+		}
+	})
+	b.Go(func() func() {
+		list, err := c.src.Disks()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if c.disks != nil {
+				c.activity = proc.DiskRates(c.disks, list)
+			}
+			c.disks = list
+		}
+	})
+	b.Go(func() func() {
+		list, err := c.src.Cpu()
+		if err != nil {
+			return nil
+		}
+		return func() {
+			if c.cpus != nil {
+				c.usage = proc.UsageAll(c.cpus, list)
+			}
+			c.cpus = list
+		}
+	})
+	updates := b.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, update := range updates {
+		update()
+	}
 	c.lastmod = time.Now()
-	wg.Wait()
 }
 
-func collect(wg *sync.WaitGroup, do func()) {
-	wg.Add(1)
-	defer wg.Done()
-	do()
+// batch runs readers concurrently and keeps the functions they return to
+// store their results.
+type batch struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	updates []func()
+}
+
+func (b *batch) Go(read func() func()) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		update := read()
+		if update == nil {
+			return
+		}
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.updates = append(b.updates, update)
+	}()
+}
+
+func (b *batch) Wait() []func() {
+	b.wg.Wait()
+	return b.updates
 }
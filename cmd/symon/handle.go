@@ -2,8 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
 	"net/http"
 	"net/netip"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,31 +23,245 @@ func handleStatus(mon *Collector) http.Handler {
 }
 
 type ProcInfo struct {
-	Pid    int    `json:"pid"`
-	Cmd    string `json:"command"`
-	Status string `json:"state"`
-	User   string `json:"user"`
-	Group  string `json:"group"`
+	Pid      int       `json:"pid"`
+	PPid     int       `json:"ppid"`
+	Pgrp     int       `json:"pgrp"`
+	Session  int       `json:"session"`
+	Tty      string    `json:"tty"`
+	Cmd      string    `json:"command"`
+	Status   string    `json:"state"`
+	User     string    `json:"user"`
+	Group    string    `json:"group"`
+	Nice     int       `json:"nice"`
+	Priority int       `json:"priority"`
+	Threads  int       `json:"threads"`
+	Cgroup   string    `json:"cgroup"`
+	Utime    float64   `json:"utime"`
+	Stime    float64   `json:"stime"`
+	Start    time.Time `json:"start"`
+	MinFlt   int64     `json:"minflt"`
+	MajFlt   int64     `json:"majflt"`
+	Vsz      int64     `json:"vsz"`
+	Rss      int64     `json:"rss"`
+	Cpu      float64   `json:"cpu"`
+
+	Container  string     `json:"container,omitempty"`
+	Namespaces Namespaces `json:"namespaces"`
+}
+
+type Namespaces struct {
+	Pid    uint64 `json:"pid"`
+	Net    uint64 `json:"net"`
+	Mnt    uint64 `json:"mnt"`
+	Uts    uint64 `json:"uts"`
+	Ipc    uint64 `json:"ipc"`
+	User   uint64 `json:"user"`
+	Cgroup uint64 `json:"cgroup"`
 }
 
-func convertProcInfo(info proc.ProcInfo) ProcInfo {
+func convertProcInfo(info proc.ProcInfo, cpu float64) ProcInfo {
 	return ProcInfo{
-		Pid:    info.Pid,
-		Cmd:    info.Cmd,
-		Status: info.Status,
-		User:   info.User,
-		Group:  info.Group,
+		Pid:      info.Pid,
+		PPid:     info.PPid,
+		Pgrp:     info.Pgrp,
+		Session:  info.Session,
+		Tty:      info.Terminal(),
+		Cmd:      info.Cmd,
+		Status:   string(info.Status),
+		User:     info.User,
+		Group:    info.Group,
+		Nice:     info.Nice,
+		Priority: info.Priority,
+		Threads:  info.Threads,
+		Cgroup:   info.Cgroup,
+		Utime:    info.Utime.Seconds(),
+		Stime:    info.Stime.Seconds(),
+		Start:    info.Start,
+		MinFlt:   info.MinFlt,
+		MajFlt:   info.MajFlt,
+		Vsz:      info.Vsz,
+		Rss:      info.Rss,
+		Cpu:      cpu,
+
+		Container: info.Container,
+		Namespaces: Namespaces{
+			Pid:    info.Ns.Pid,
+			Net:    info.Ns.Net,
+			Mnt:    info.Ns.Mnt,
+			Uts:    info.Ns.Uts,
+			Ipc:    info.Ns.Ipc,
+			User:   info.Ns.User,
+			Cgroup: info.Ns.Cgroup,
+		},
 	}
 }
 
 func handleProcess(mon *Collector) http.Handler {
 	fn := func(r *http.Request) (interface{}, error) {
 		var (
-			list = mon.Process()
-			res  = make([]ProcInfo, 0, len(list))
+			list, load = mon.Process()
+			res        = make([]ProcInfo, 0, len(list))
+			container  = r.URL.Query().Get("container")
 		)
 		for i := range list {
-			res = append(res, convertProcInfo(list[i]))
+			if container != "" && !strings.HasPrefix(list[i].Container, container) {
+				continue
+			}
+			res = append(res, convertProcInfo(list[i], load[list[i].Pid]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type IdSet struct {
+	Real      int `json:"real"`
+	Effective int `json:"effective"`
+	Saved     int `json:"saved"`
+	Fs        int `json:"fs"`
+}
+
+type ProcDetail struct {
+	ProcInfo
+	Args     []string          `json:"args"`
+	Exe      string            `json:"exe"`
+	Deleted  bool              `json:"deleted"`
+	Cwd      string            `json:"cwd"`
+	Root     string            `json:"root"`
+	Environ  map[string]string `json:"environ"`
+	LoginUid int               `json:"loginuid"`
+	Uid      IdSet             `json:"uid"`
+	Gid      IdSet             `json:"gid"`
+
+	Policy           string      `json:"policy"`
+	RtPriority       int         `json:"rtpriority"`
+	VoluntaryCtxt    int64       `json:"voluntary_ctxt"`
+	NonVoluntaryCtxt int64       `json:"nonvoluntary_ctxt"`
+	Affinity         []int       `json:"affinity"`
+	Fds              int         `json:"fds"`
+	Limits           []LimitInfo `json:"limits"`
+	OomScore         int         `json:"oom_score"`
+	OomScoreAdj      int         `json:"oom_score_adj"`
+}
+
+type LimitInfo struct {
+	Resource string `json:"resource"`
+	Desc     string `json:"description"`
+	Soft     int64  `json:"soft"`
+	Hard     int64  `json:"hard"`
+	Unit     string `json:"unit,omitempty"`
+}
+
+func convertLimitInfo(limit proc.LimitInfo) LimitInfo {
+	return LimitInfo{
+		Resource: limit.Resource,
+		Desc:     limit.Desc,
+		Soft:     limit.Soft,
+		Hard:     limit.Hard,
+		Unit:     limit.Unit,
+	}
+}
+
+func convertIdSet(set proc.IdSet) IdSet {
+	return IdSet{
+		Real:      set.Real,
+		Effective: set.Effective,
+		Saved:     set.Saved,
+		Fs:        set.Fs,
+	}
+}
+
+func convertProcDetail(detail proc.ProcDetail, cpu float64) ProcDetail {
+	res := ProcDetail{
+		ProcInfo: convertProcInfo(detail.ProcInfo, cpu),
+		Args:     detail.Args,
+		Exe:      detail.Exe,
+		Deleted:  detail.Deleted,
+		Cwd:      detail.Cwd,
+		Root:     detail.Root,
+		Environ:  detail.Environ,
+		LoginUid: detail.LoginUid,
+		Uid:      convertIdSet(detail.Uid),
+		Gid:      convertIdSet(detail.Gid),
+
+		Policy:           detail.Policy.String(),
+		RtPriority:       detail.RtPriority,
+		VoluntaryCtxt:    detail.VoluntaryCtxt,
+		NonVoluntaryCtxt: detail.NonVoluntaryCtxt,
+		Affinity:         detail.Affinity,
+		Fds:              detail.Fds,
+		OomScore:         detail.OomScore,
+		OomScoreAdj:      detail.OomScoreAdj,
+	}
+	for _, limit := range detail.Limits {
+		res.Limits = append(res.Limits, convertLimitInfo(limit))
+	}
+	return res
+}
+
+func handleProcessDetail(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		pid, err := strconv.Atoi(path.Base(r.URL.Path))
+		if err != nil {
+			return nil, fs.ErrNotExist
+		}
+		detail, err := mon.Detail(pid)
+		if err != nil {
+			return nil, err
+		}
+		_, load := mon.Process()
+		return convertProcDetail(detail, load[pid]), nil
+	}
+	return handle(fn)
+}
+
+type ProcTotal struct {
+	Count   int     `json:"count"`
+	Threads int     `json:"threads"`
+	Utime   float64 `json:"utime"`
+	Stime   float64 `json:"stime"`
+	MinFlt  int64   `json:"minflt"`
+	MajFlt  int64   `json:"majflt"`
+	Vsz     int64   `json:"vsz"`
+	Rss     int64   `json:"rss"`
+}
+
+type ProcNode struct {
+	ProcInfo
+	Total    ProcTotal  `json:"total"`
+	Children []ProcNode `json:"children,omitempty"`
+}
+
+func convertProcNode(node *proc.ProcNode, load map[int]float64) ProcNode {
+	total := node.Total()
+	res := ProcNode{
+		ProcInfo: convertProcInfo(node.ProcInfo, load[node.Pid]),
+		Total: ProcTotal{
+			Count:   total.Count,
+			Threads: total.Threads,
+			Utime:   total.Utime.Seconds(),
+			Stime:   total.Stime.Seconds(),
+			MinFlt:  total.MinFlt,
+			MajFlt:  total.MajFlt,
+			Vsz:     total.Vsz,
+			Rss:     total.Rss,
+		},
+	}
+	for _, c := range node.Children {
+		res.Children = append(res.Children, convertProcNode(c, load))
+	}
+	return res
+}
+
+func handleProcessTree(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list, load = mon.Process()
+			tree       = proc.NewProcessTree(list)
+			res        = make([]ProcNode, 0, len(tree.Roots))
+		)
+		for _, n := range tree.Roots {
+			res = append(res, convertProcNode(n, load))
 		}
 		return res, nil
 	}
@@ -50,14 +269,22 @@ func handleProcess(mon *Collector) http.Handler {
 }
 
 type MemInfo struct {
-	Total int64 `json:"total`
-	Used  int64 `json:"used`
+	Total     int64 `json:"total"`
+	Used      int64 `json:"used"`
+	Free      int64 `json:"free"`
+	Shared    int64 `json:"shared"`
+	BuffCache int64 `json:"buffcache"`
+	Available int64 `json:"available"`
 }
 
 func convertMemInfo(info proc.MemInfo) MemInfo {
 	return MemInfo{
-		Total: info.Total,
-		Used:  info.Used(),
+		Total:     info.Total,
+		Used:      info.Used(),
+		Free:      info.Free,
+		Shared:    info.Shared,
+		BuffCache: info.BuffCache(),
+		Available: info.Available,
 	}
 }
 
@@ -76,6 +303,43 @@ func handleFree(mon *Collector) http.Handler {
 	return handle(fn)
 }
 
+type VmActivity struct {
+	PageIn     float64 `json:"pagein"`
+	PageOut    float64 `json:"pageout"`
+	SwapIn     float64 `json:"swapin"`
+	SwapOut    float64 `json:"swapout"`
+	Faults     float64 `json:"faults"`
+	MajFaults  float64 `json:"majfaults"`
+	Scan       float64 `json:"scan"`
+	Steal      float64 `json:"steal"`
+	OomKill    float64 `json:"oomkill"`
+	OomKills   int64   `json:"oomkills"`
+	DirtyPages int64   `json:"dirty"`
+}
+
+func convertVmActivity(stat proc.VmStat, rate proc.VmActivity) VmActivity {
+	return VmActivity{
+		PageIn:     rate.PageIn,
+		PageOut:    rate.PageOut,
+		SwapIn:     rate.SwapIn,
+		SwapOut:    rate.SwapOut,
+		Faults:     rate.Faults,
+		MajFaults:  rate.MajFaults,
+		Scan:       rate.Scan,
+		Steal:      rate.Steal,
+		OomKill:    rate.OomKill,
+		OomKills:   stat.OomKill,
+		DirtyPages: stat.DirtyPages,
+	}
+}
+
+func handleVmstat(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		return convertVmActivity(mon.VirtualMemory()), nil
+	}
+	return handle(fn)
+}
+
 func handleLoadAvg(mon *Collector) http.Handler {
 	fn := func(r *http.Request) (interface{}, error) {
 		list := mon.LoadAvg()
@@ -84,12 +348,499 @@ func handleLoadAvg(mon *Collector) http.Handler {
 	return handle(fn)
 }
 
+type CpuUsage struct {
+	Ident  string  `json:"cpu"`
+	Busy   float64 `json:"busy"`
+	User   float64 `json:"user"`
+	Sys    float64 `json:"system"`
+	Iowait float64 `json:"iowait"`
+	Steal  float64 `json:"steal"`
+}
+
+func convertCpuUsage(usage proc.CpuUsage) CpuUsage {
+	return CpuUsage{
+		Ident:  usage.Ident,
+		Busy:   usage.Busy,
+		User:   usage.User,
+		Sys:    usage.Sys,
+		Iowait: usage.Iowait,
+		Steal:  usage.Steal,
+	}
+}
+
+func handleCpu(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Cpu()
+			res  = make([]CpuUsage, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertCpuUsage(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type CpuTopology struct {
+	Cpu       int      `json:"cpu"`
+	Online    bool     `json:"online"`
+	Core      int      `json:"core"`
+	Package   int      `json:"package"`
+	Die       int      `json:"die"`
+	Node      int      `json:"node"`
+	ModelName string   `json:"model,omitempty"`
+	Vendor    string   `json:"vendor,omitempty"`
+	CacheSize int64    `json:"cachesize,omitempty"`
+	Bogomips  float64  `json:"bogomips,omitempty"`
+	Flags     []string `json:"flags,omitempty"`
+	CurFreq   float64  `json:"freq"`
+	MinFreq   float64  `json:"minfreq"`
+	MaxFreq   float64  `json:"maxfreq"`
+	Governor  string   `json:"governor,omitempty"`
+}
+
+func convertCpuTopology(topo proc.CpuTopology, model proc.ProcessorInfo) CpuTopology {
+	return CpuTopology{
+		Cpu:       topo.Cpu,
+		Online:    topo.Online,
+		Core:      topo.Core,
+		Package:   topo.Package,
+		Die:       topo.Die,
+		Node:      topo.Node,
+		ModelName: model.ModelName,
+		Vendor:    model.Vendor,
+		CacheSize: model.CacheSize,
+		Bogomips:  model.Bogomips,
+		Flags:     model.Flags,
+		CurFreq:   topo.CurFreq,
+		MinFreq:   topo.MinFreq,
+		MaxFreq:   topo.MaxFreq,
+		Governor:  topo.Governor,
+	}
+}
+
+func handleCpuInfo(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			models, topo = mon.Processors()
+			index        = make(map[int]proc.ProcessorInfo)
+			res          = make([]CpuTopology, 0, len(topo))
+		)
+		for _, m := range models {
+			index[m.Processor] = m
+		}
+		for i := range topo {
+			res = append(res, convertCpuTopology(topo[i], index[topo[i].Cpu]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type KernelInfo struct {
+	BootTime time.Time `json:"boottime"`
+	Running  int       `json:"running"`
+	Blocked  int       `json:"blocked"`
+	Ctxt     float64   `json:"ctxt"`
+	Intr     float64   `json:"intr"`
+	SoftIrq  float64   `json:"softirq"`
+	Forks    float64   `json:"forks"`
+}
+
+func convertKernel(stat proc.KernelStat, rate proc.KernelRate) KernelInfo {
+	return KernelInfo{
+		BootTime: stat.BootTime,
+		Running:  stat.Running,
+		Blocked:  stat.Blocked,
+		Ctxt:     rate.Ctxt,
+		Intr:     rate.Intr,
+		SoftIrq:  rate.SoftIrq,
+		Forks:    rate.Forks,
+	}
+}
+
+func handleKernel(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		return convertKernel(mon.Kernel()), nil
+	}
+	return handle(fn)
+}
+
+type IfaceCounters struct {
+	Bytes      int64 `json:"bytes"`
+	Packets    int64 `json:"packets"`
+	Errors     int64 `json:"errors"`
+	Drops      int64 `json:"drops"`
+	Fifo       int64 `json:"fifo"`
+	Compressed int64 `json:"compressed"`
+}
+
+type IfaceRate struct {
+	Bytes   float64 `json:"bytes"`
+	Packets float64 `json:"packets"`
+	Errors  float64 `json:"errors"`
+	Drops   float64 `json:"drops"`
+}
+
+type IfaceInfo struct {
+	Name      string        `json:"interface"`
+	Rx        IfaceCounters `json:"rx"`
+	Tx        IfaceCounters `json:"tx"`
+	RxRate    IfaceRate     `json:"rxrate"`
+	TxRate    IfaceRate     `json:"txrate"`
+	Frame     int64         `json:"frame"`
+	Multicast int64         `json:"multicast"`
+	Colls     int64         `json:"collisions"`
+	Carrier   int64         `json:"carrier"`
+}
+
+func convertIfaceInfo(info proc.IfaceInfo, rate proc.IfaceRate) IfaceInfo {
+	return IfaceInfo{
+		Name: info.Name,
+		Rx: IfaceCounters{
+			Bytes:      info.RxBytes,
+			Packets:    info.RxPackets,
+			Errors:     info.RxErrors,
+			Drops:      info.RxDrops,
+			Fifo:       info.RxFifo,
+			Compressed: info.RxCompressed,
+		},
+		Tx: IfaceCounters{
+			Bytes:      info.TxBytes,
+			Packets:    info.TxPackets,
+			Errors:     info.TxErrors,
+			Drops:      info.TxDrops,
+			Fifo:       info.TxFifo,
+			Compressed: info.TxCompressed,
+		},
+		RxRate: IfaceRate{
+			Bytes:   rate.RxBytes,
+			Packets: rate.RxPackets,
+			Errors:  rate.RxErrors,
+			Drops:   rate.RxDrops,
+		},
+		TxRate: IfaceRate{
+			Bytes:   rate.TxBytes,
+			Packets: rate.TxPackets,
+			Errors:  rate.TxErrors,
+			Drops:   rate.TxDrops,
+		},
+		Frame:     info.RxFrame,
+		Multicast: info.RxMulticast,
+		Colls:     info.TxColls,
+		Carrier:   info.TxCarrier,
+	}
+}
+
+func handleInterfaces(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list, rates = mon.Interfaces()
+			res         = make([]IfaceInfo, 0, len(list))
+			index       = make(map[string]proc.IfaceRate)
+		)
+		for _, r := range rates {
+			index[r.Name] = r
+		}
+		for i := range list {
+			res = append(res, convertIfaceInfo(list[i], index[list[i].Name]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type NeighInfo struct {
+	Addr     netip.Addr `json:"addr"`
+	HwType   int        `json:"hwtype"`
+	HwAddr   string     `json:"hwaddr"`
+	Flags    string     `json:"flags"`
+	Complete bool       `json:"complete"`
+	Device   string     `json:"device"`
+}
+
+func convertNeighInfo(info proc.NeighInfo) NeighInfo {
+	return NeighInfo{
+		Addr:     info.Addr,
+		HwType:   info.HwType,
+		HwAddr:   info.HardwareAddr(),
+		Flags:    info.Flags.String(),
+		Complete: info.Complete(),
+		Device:   info.Device,
+	}
+}
+
+func handleNeighbours(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Neighbours()
+			res  = make([]NeighInfo, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertNeighInfo(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type DiskActivity struct {
+	Name         string  `json:"device"`
+	Reads        float64 `json:"reads"`
+	Writes       float64 `json:"writes"`
+	ReadsMerged  float64 `json:"rmerged"`
+	WritesMerged float64 `json:"wmerged"`
+	ReadBytes    float64 `json:"rbytes"`
+	WriteBytes   float64 `json:"wbytes"`
+	Discards     float64 `json:"discards"`
+	ReadAwait    float64 `json:"rawait"`
+	WriteAwait   float64 `json:"wawait"`
+	Await        float64 `json:"await"`
+	QueueSize    float64 `json:"queue"`
+	Util         float64 `json:"util"`
+}
+
+func convertDiskActivity(info proc.DiskActivity) DiskActivity {
+	return DiskActivity{
+		Name:         info.Name,
+		Reads:        info.Reads,
+		Writes:       info.Writes,
+		ReadsMerged:  info.ReadsMerged,
+		WritesMerged: info.WritesMerged,
+		ReadBytes:    info.ReadBytes,
+		WriteBytes:   info.WriteBytes,
+		Discards:     info.Discards,
+		ReadAwait:    info.ReadAwait.Seconds(),
+		WriteAwait:   info.WriteAwait.Seconds(),
+		Await:        info.Await.Seconds(),
+		QueueSize:    info.QueueSize,
+		Util:         info.Util,
+	}
+}
+
+func handleDisks(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Disks()
+			res  = make([]DiskActivity, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertDiskActivity(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type FsInfo struct {
+	Source       string  `json:"source"`
+	Point        string  `json:"mountpoint"`
+	Type         string  `json:"type"`
+	ReadOnly     bool    `json:"readonly"`
+	Size         int64   `json:"size"`
+	Used         int64   `json:"used"`
+	Avail        int64   `json:"available"`
+	UsedPercent  float64 `json:"usedpercent"`
+	Files        int64   `json:"inodes"`
+	FilesUsed    int64   `json:"inodesused"`
+	FilesPercent float64 `json:"inodespercent"`
+}
+
+func convertFsInfo(info proc.FsInfo) FsInfo {
+	return FsInfo{
+		Source:       info.Source,
+		Point:        info.Point,
+		Type:         info.Type,
+		ReadOnly:     info.ReadOnly(),
+		Size:         info.Size,
+		Used:         info.Used(),
+		Avail:        info.Avail,
+		UsedPercent:  info.UsedPercent(),
+		Files:        info.Files,
+		FilesUsed:    info.FilesUsed(),
+		FilesPercent: info.FilesPercent(),
+	}
+}
+
+func handleFilesystems(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Filesystems()
+			all  = r.URL.Query().Has("all")
+			res  = make([]FsInfo, 0, len(list))
+		)
+		for i := range list {
+			if !all && (list[i].Pseudo() || list[i].Size == 0) {
+				continue
+			}
+			res = append(res, convertFsInfo(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type PressureStat struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	Total  int64   `json:"total"`
+}
+
+type PressureInfo struct {
+	Resource string       `json:"resource"`
+	Some     PressureStat `json:"some"`
+	Full     PressureStat `json:"full"`
+}
+
+func convertPressureStat(stat proc.PressureStat) PressureStat {
+	return PressureStat{
+		Avg10:  stat.Avg10,
+		Avg60:  stat.Avg60,
+		Avg300: stat.Avg300,
+		Total:  stat.Total.Microseconds(),
+	}
+}
+
+func convertPressureInfo(info proc.PressureInfo) PressureInfo {
+	return PressureInfo{
+		Resource: info.Resource,
+		Some:     convertPressureStat(info.Some),
+		Full:     convertPressureStat(info.Full),
+	}
+}
+
+func handlePressure(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Pressure()
+			res  = make([]PressureInfo, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertPressureInfo(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
+type CgroupNode struct {
+	Path       string         `json:"path"`
+	Cpu        float64        `json:"cpu"`
+	Memory     int64          `json:"memory"`
+	MemoryMax  int64          `json:"memorymax"`
+	Pids       int64          `json:"pids"`
+	ReadBytes  float64        `json:"rbytes"`
+	WriteBytes float64        `json:"wbytes"`
+	ReadIos    float64        `json:"rios"`
+	WriteIos   float64        `json:"wios"`
+	Throttled  int64          `json:"throttled"`
+	Pressure   []PressureInfo `json:"pressure,omitempty"`
+	Children   []*CgroupNode  `json:"children,omitempty"`
+}
+
+func convertCgroupInfo(info proc.CgroupInfo, rate proc.CgroupActivity) *CgroupNode {
+	node := CgroupNode{
+		Path:       info.Path,
+		Cpu:        rate.Cpu,
+		Memory:     info.MemoryCurrent,
+		MemoryMax:  info.MemoryMax,
+		Pids:       info.PidsCurrent,
+		ReadBytes:  rate.ReadBytes,
+		WriteBytes: rate.WriteBytes,
+		ReadIos:    rate.ReadIos,
+		WriteIos:   rate.WriteIos,
+		Throttled:  info.Cpu.Throttled,
+	}
+	for _, p := range info.Pressure {
+		node.Pressure = append(node.Pressure, convertPressureInfo(p))
+	}
+	return &node
+}
+
+func handleCgroups(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list, rates = mon.Cgroups()
+			index       = make(map[string]proc.CgroupActivity)
+			nodes       = make(map[string]*CgroupNode)
+			roots       []*CgroupNode
+		)
+		for _, r := range rates {
+			index[r.Path] = r
+		}
+		for i := range list {
+			nodes[list[i].Path] = convertCgroupInfo(list[i], index[list[i].Path])
+		}
+		for i := range list {
+			var (
+				node       = nodes[list[i].Path]
+				parent, ok = nodes[path.Dir(list[i].Path)]
+			)
+			if !ok || parent == node {
+				roots = append(roots, node)
+				continue
+			}
+			parent.Children = append(parent.Children, node)
+		}
+		return roots, nil
+	}
+	return handle(fn)
+}
+
+type SensorInfo struct {
+	Chip   string   `json:"chip"`
+	Device string   `json:"device"`
+	Kind   string   `json:"type"`
+	Unit   string   `json:"unit"`
+	Label  string   `json:"label"`
+	Input  float64  `json:"input"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+	Crit   *float64 `json:"crit,omitempty"`
+}
+
+func convertSensorInfo(info proc.SensorInfo) SensorInfo {
+	threshold := func(value float64) *float64 {
+		if math.IsNaN(value) {
+			return nil
+		}
+		return &value
+	}
+	return SensorInfo{
+		Chip:   info.Chip,
+		Device: info.Device,
+		Kind:   info.Kind.String(),
+		Unit:   info.Kind.Unit(),
+		Label:  info.Label,
+		Input:  info.Input,
+		Min:    threshold(info.Min),
+		Max:    threshold(info.Max),
+		Crit:   threshold(info.Crit),
+	}
+}
+
+func handleSensors(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Sensors()
+			res  = make([]SensorInfo, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertSensorInfo(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
 type UserInfo struct {
 	Type string     `json:"session"`
 	User string     `json:"user"`
 	Host string     `json:"host"`
 	When time.Time  `json:"time"`
-	Addr netip.Addr `json:"addr`
+	Addr netip.Addr `json:"addr"`
 }
 
 func convertWho(info proc.Who) UserInfo {
@@ -116,37 +867,52 @@ func handleUsers(mon *Collector) http.Handler {
 }
 
 type ConnInfo struct {
-	Local  netip.AddrPort `json:"local"`
-	Remote netip.AddrPort `json:"remote"`
-	User   string         `json:"user"`
-	Proto  string         `json:"protocol"`
-	State  string         `json:"state"`
+	Local   netip.AddrPort `json:"local"`
+	Remote  netip.AddrPort `json:"remote"`
+	User    string         `json:"user"`
+	Proto   string         `json:"protocol"`
+	State   string         `json:"state"`
+	Uid     int            `json:"uid"`
+	Inode   uint64         `json:"inode"`
+	RecvQ   int64          `json:"recvq"`
+	SendQ   int64          `json:"sendq"`
+	Timer   string         `json:"timer"`
+	Expires float64        `json:"expires"`
+	Retrans int            `json:"retransmits"`
+	Pid     int            `json:"pid,omitempty"`
+	Command string         `json:"command,omitempty"`
 }
 
 func convertConnInfo(info proc.ConnInfo) ConnInfo {
 	return ConnInfo{
-		Local:  info.Local,
-		Remote: info.Remote,
-		User:   info.User,
-		State:  info.State.String(),
-		Proto:  info.Proto,
+		Local:   info.Local,
+		Remote:  info.Remote,
+		User:    info.User,
+		State:   info.State.String(),
+		Proto:   info.Proto,
+		Uid:     info.Uid,
+		Inode:   info.Inode,
+		RecvQ:   info.RxQueue,
+		SendQ:   info.TxQueue,
+		Timer:   info.Timer.String(),
+		Expires: info.Expires.Seconds(),
+		Retrans: info.Retransmits,
+		Pid:     info.Pid,
+		Command: info.Command,
 	}
 }
 
 func handleNetstat(mon *Collector) http.Handler {
 	fn := func(r *http.Request) (interface{}, error) {
 		var (
-			list  []proc.ConnInfo
+			list  = mon.Conns()
 			res   []ConnInfo
-			query = r.URL.Query()
+			proto = strings.ToLower(r.URL.Query().Get("proto"))
 		)
-		switch strings.ToLower(query.Get("proto")) {
-		case "udp":
-		case "tcp":
-		default:
-			list = mon.Conns()
-		}
 		for i := range list {
+			if proto != "" && list[i].Proto != proto && strings.TrimSuffix(list[i].Proto, "6") != proto {
+				continue
+			}
 			res = append(res, convertConnInfo(list[i]))
 		}
 		return res, nil
@@ -154,6 +920,44 @@ func handleNetstat(mon *Collector) http.Handler {
 	return handle(fn)
 }
 
+type UnixInfo struct {
+	Path     string `json:"path"`
+	Abstract bool   `json:"abstract"`
+	Type     string `json:"type"`
+	State    string `json:"state"`
+	Listen   bool   `json:"listen"`
+	Inode    uint64 `json:"inode"`
+	Pid      int    `json:"pid,omitempty"`
+	Command  string `json:"command,omitempty"`
+}
+
+func convertUnixInfo(info proc.UnixInfo) UnixInfo {
+	return UnixInfo{
+		Path:     info.Path,
+		Abstract: info.Abstract,
+		Type:     info.Type.String(),
+		State:    info.State.String(),
+		Listen:   info.Listen,
+		Inode:    info.Inode,
+		Pid:      info.Pid,
+		Command:  info.Command,
+	}
+}
+
+func handleUnix(mon *Collector) http.Handler {
+	fn := func(r *http.Request) (interface{}, error) {
+		var (
+			list = mon.Unix()
+			res  = make([]UnixInfo, 0, len(list))
+		)
+		for i := range list {
+			res = append(res, convertUnixInfo(list[i]))
+		}
+		return res, nil
+	}
+	return handle(fn)
+}
+
 type handler func(r *http.Request) (interface{}, error)
 
 func handle(h handler) http.Handler {
@@ -162,7 +966,11 @@ func handle(h handler) http.Handler {
 
 		w.Header().Set("content-type", "application/json")
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			code := http.StatusInternalServerError
+			if errors.Is(err, fs.ErrNotExist) {
+				code = http.StatusNotFound
+			}
+			w.WriteHeader(code)
 			return
 		}
 		json.NewEncoder(w).Encode(data)
@@ -6,24 +6,41 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/midbel/symon/proc"
 )
 
 func main() {
 	var (
 		addr  = flag.String("a", ":8080", "listening address")
 		delay = flag.Duration("d", time.Second, "update interval")
+		root  = flag.String("r", "/", "root directory of the monitored system")
 	)
 	flag.Parse()
 
-	mon := Monitor()
+	mon := Monitor(proc.NewSource(*root))
 	go mon.Run(*delay)
 
 	http.Handle("/", handleStatus(mon))
 	http.Handle("/process", handleProcess(mon))
+	http.Handle("/process/", handleProcessDetail(mon))
+	http.Handle("/process/tree", handleProcessTree(mon))
 	http.Handle("/memory", handleFree(mon))
+	http.Handle("/vmstat", handleVmstat(mon))
 	http.Handle("/loadavg", handleLoadAvg(mon))
+	http.Handle("/pressure", handlePressure(mon))
 	http.Handle("/users", handleUsers(mon))
 	http.Handle("/netstat", handleNetstat(mon))
+	http.Handle("/netstat/unix", handleUnix(mon))
+	http.Handle("/cpu", handleCpu(mon))
+	http.Handle("/cpu/info", handleCpuInfo(mon))
+	http.Handle("/kernel", handleKernel(mon))
+	http.Handle("/interfaces", handleInterfaces(mon))
+	http.Handle("/neighbours", handleNeighbours(mon))
+	http.Handle("/disks", handleDisks(mon))
+	http.Handle("/filesystems", handleFilesystems(mon))
+	http.Handle("/cgroups", handleCgroups(mon))
+	http.Handle("/sensors", handleSensors(mon))
 
 	if err := http.ListenAndServe(*addr, nil); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/midbel/symon/proc"
+)
+
+func main() {
+	var (
+		root = flag.String("r", "/", "root directory of the system")
+		all  = flag.Bool("a", false, "print all the fields of os-release")
+	)
+	flag.Parse()
+
+	info, err := proc.NewSource(*root).Identity()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	printField("hostname", info.Hostname)
+	printField("domainname", info.Domainname)
+	printField("machine-id", info.MachineId)
+	printField("os", info.PrettyName())
+	printField("kernel", info.Release)
+	printField("version", info.Version)
+	printField("container", info.Container)
+	printField("container-id", info.ContainerId)
+	printField("virtualization", info.Virtual)
+	if !*all {
+		return
+	}
+	keys := make([]string, 0, len(info.OS))
+	for k := range info.OS {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Println()
+	for _, k := range keys {
+		printField(k, info.OS[k])
+	}
+}
+
+func printField(name, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Printf("%-16s: %s", name, value)
+	fmt.Println()
+}
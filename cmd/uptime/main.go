@@ -24,6 +24,12 @@ func main() {
 			avg, _ = proc.LoadAvg()
 		)
 		fmt.Fprintf(os.Stdout, "up: %s, load average: %.2f, %.2f, %.2f", up, slices.Fst(avg), slices.Snd(avg), slices.Lst(avg))
+		if list, err := proc.Pressure(); err == nil {
+			fmt.Fprint(os.Stdout, ", pressure:")
+			for _, p := range list {
+				fmt.Fprintf(os.Stdout, " %s %.2f", p.Resource, p.Some.Avg10)
+			}
+		}
 		fmt.Fprintln(os.Stdout)
 	case *pretty:
 		el := prettyTime()
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/midbel/symon/proc"
+)
+
+type sample struct {
+	Cpu    proc.CpuInfo
+	Kernel proc.KernelStat
+	Vm     proc.VmStat
+}
+
+func main() {
+	flag.Parse()
+
+	var (
+		delay time.Duration
+		count = 1
+	)
+	if flag.NArg() > 0 {
+		sec, err := strconv.Atoi(flag.Arg(0))
+		if err != nil || sec <= 0 {
+			fmt.Fprintf(os.Stderr, "%s: invalid delay", flag.Arg(0))
+			fmt.Fprintln(os.Stderr)
+			os.Exit(2)
+		}
+		delay, count = time.Duration(sec)*time.Second, 0
+	}
+	if flag.NArg() > 1 {
+		n, err := strconv.Atoi(flag.Arg(1))
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "%s: invalid count", flag.Arg(1))
+			fmt.Fprintln(os.Stderr)
+			os.Exit(2)
+		}
+		count = n
+	}
+
+	curr, err := collect()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// the first report shows the averages since the system boot
+	prev := sample{
+		Kernel: proc.KernelStat{When: curr.Kernel.BootTime},
+		Vm:     proc.VmStat{When: curr.Kernel.BootTime},
+	}
+
+	fmt.Println("procs -----------memory---------- ---swap-- -----io---- -system-- -------cpu-------")
+	fmt.Printf("%3s %3s %8s %8s %8s %8s %4s %4s %5s %5s %5s %5s %3s %3s %3s %3s %3s", "r", "b", "swpd", "free", "buff", "cache", "si", "so", "bi", "bo", "in", "cs", "us", "sy", "id", "wa", "st")
+	fmt.Println()
+	for i := 0; ; i++ {
+		if err := report(prev, curr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if count > 0 && i+1 >= count {
+			break
+		}
+		time.Sleep(delay)
+		prev = curr
+		if curr, err = collect(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func collect() (sample, error) {
+	var (
+		s   sample
+		err error
+	)
+	cpus, err := proc.Cpu()
+	if err != nil {
+		return s, err
+	}
+	for _, c := range cpus {
+		if c.Ident == "cpu" {
+			s.Cpu = c
+			break
+		}
+	}
+	if s.Kernel, err = proc.Kernel(); err != nil {
+		return s, err
+	}
+	if s.Vm, err = proc.VirtualMemory(); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+func report(prev, curr sample) error {
+	mem, swap, err := proc.Free()
+	if err != nil {
+		return err
+	}
+	var (
+		cpu  = proc.Usage(prev.Cpu, curr.Cpu)
+		kern = proc.KernelRates(prev.Kernel, curr.Kernel)
+		vm   = proc.VmRate(prev.Vm, curr.Vm)
+		idle = 100 - cpu.Busy - cpu.Iowait
+	)
+	fmt.Printf("%3d %3d %8d %8d %8d %8d %4.0f %4.0f %5.0f %5.0f %5.0f %5.0f %3.0f %3.0f %3.0f %3.0f %3.0f",
+		curr.Kernel.Running, curr.Kernel.Blocked,
+		swap.Used(), mem.Free, mem.Buffers, mem.Cached,
+		vm.SwapIn, vm.SwapOut, vm.PageIn, vm.PageOut,
+		kern.Intr, kern.Ctxt,
+		cpu.User, cpu.Sys, idle, cpu.Iowait, cpu.Steal)
+	fmt.Println()
+	return nil
+}
@@ -2,7 +2,4 @@ module github.com/midbel/symon
 
 go 1.20
 
-require (
-	github.com/midbel/shlex v0.2.3
-	github.com/midbel/slices v0.7.1
-)
+require github.com/midbel/slices v0.7.1
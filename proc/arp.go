@@ -0,0 +1,107 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/slices"
+)
+
+type NeighFlags int
+
+const (
+	NeighComplete NeighFlags = 0x02
+	NeighPerm     NeighFlags = 0x04
+	NeighPublish  NeighFlags = 0x08
+)
+
+// String returns the flags in the format used by arp -n.
+func (f NeighFlags) String() string {
+	var str strings.Builder
+	if f&NeighComplete != 0 {
+		str.WriteByte('C')
+	}
+	if f&NeighPerm != 0 {
+		str.WriteByte('M')
+	}
+	if f&NeighPublish != 0 {
+		str.WriteByte('P')
+	}
+	return str.String()
+}
+
+// NeighInfo is an entry of the neighbour table. HwAddr is nil when the
+// hardware address can not be parsed as a MAC address (eg: ip6gre); the
+// address as written by the kernel is always available in RawHwAddr.
+type NeighInfo struct {
+	Addr      netip.Addr
+	HwType    int
+	Flags     NeighFlags
+	HwAddr    net.HardwareAddr
+	RawHwAddr string
+	Mask      string
+	Device    string
+}
+
+// HardwareAddr returns the hardware address of the neighbour.
+func (n NeighInfo) HardwareAddr() string {
+	if n.HwAddr == nil {
+		return n.RawHwAddr
+	}
+	return n.HwAddr.String()
+}
+
+func (n NeighInfo) Complete() bool {
+	return n.Flags&NeighComplete != 0
+}
+
+func Neighbours() ([]NeighInfo, error) {
+	return system.Neighbours()
+}
+
+func (s Source) Neighbours() ([]NeighInfo, error) {
+	r, err := os.Open(s.path(arpFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		list []NeighInfo
+		scan = bufio.NewScanner(r)
+	)
+	scan.Scan()
+	for scan.Scan() {
+		var (
+			fields = strings.Fields(scan.Text())
+			neigh  NeighInfo
+		)
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("not enough fields in arp table")
+		}
+		if neigh.Addr, err = netip.ParseAddr(slices.At(fields, 0)); err != nil {
+			return nil, err
+		}
+		typ, err := strconv.ParseInt(slices.At(fields, 1), 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		neigh.HwType = int(typ)
+		flags, err := strconv.ParseInt(slices.At(fields, 2), 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		neigh.Flags = NeighFlags(flags)
+		neigh.RawHwAddr = slices.At(fields, 3)
+		neigh.HwAddr, _ = net.ParseMAC(neigh.RawHwAddr)
+		neigh.Mask = slices.At(fields, 4)
+		neigh.Device = slices.At(fields, 5)
+		list = append(list, neigh)
+	}
+	return list, scan.Err()
+}
@@ -0,0 +1,31 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestNeighbours(t *testing.T) {
+	list, err := fixtures.Neighbours()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		Addr     string
+		HwAddr   string
+		Complete bool
+		Device   string
+	}{
+		{Addr: "192.168.1.1", HwAddr: "52:54:00:12:34:56", Complete: true, Device: "eth0"},
+		{Addr: "192.168.1.7", HwAddr: "00:00:00:00:00:00", Complete: false, Device: "eth0"},
+		{Addr: "10.10.0.2", HwAddr: "0a:00:00:02", Complete: true, Device: "gre1"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("neighbours mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i, w := range want {
+		got := list[i]
+		if got.Addr.String() != w.Addr || got.HardwareAddr() != w.HwAddr || got.Complete() != w.Complete || got.Device != w.Device {
+			t.Errorf("%s: neighbour mismatched! want %+v, got %+v", w.Addr, w, got)
+		}
+	}
+}
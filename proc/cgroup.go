@@ -0,0 +1,372 @@
+package proc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoCgroup is returned when the unified cgroup hierarchy (cgroup v2) is
+// not mounted.
+var ErrNoCgroup = errors.New("cgroup v2 hierarchy not mounted")
+
+type CgroupCpu struct {
+	Usage     time.Duration
+	User      time.Duration
+	System    time.Duration
+	Periods   int64
+	Throttled int64
+	Wait      time.Duration
+}
+
+type CgroupIo struct {
+	Major  int
+	Minor  int
+	Rbytes int64
+	Wbytes int64
+	Rios   int64
+	Wios   int64
+	Dbytes int64
+	Dios   int64
+}
+
+type CgroupInfo struct {
+	Path string
+
+	Cpu           CgroupCpu
+	MemoryCurrent int64
+	MemoryMax     int64
+	MemoryStat    map[string]int64
+	Io            []CgroupIo
+	PidsCurrent   int64
+	Pressure      []PressureInfo
+
+	When time.Time
+}
+
+// Unlimited reports whether no memory limit is set on the cgroup.
+func (c CgroupInfo) Unlimited() bool {
+	return c.MemoryMax < 0
+}
+
+func Cgroups() ([]CgroupInfo, error) {
+	return system.Cgroups()
+}
+
+// Cgroups walks the cgroup v2 hierarchy and returns the resources used by
+// each cgroup. Paths are relative to the root of the hierarchy.
+func (s Source) Cgroups() ([]CgroupInfo, error) {
+	root, err := s.cgroupRoot()
+	if err != nil {
+		return nil, err
+	}
+	var list []CgroupInfo
+	err = filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, file)
+		ifo, err := readCgroup(file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		ifo.Path = filepath.Join("/", rel)
+		list = append(list, ifo)
+		return nil
+	})
+	return list, err
+}
+
+func Cgroup(path string) (CgroupInfo, error) {
+	return system.Cgroup(path)
+}
+
+// Cgroup returns the resources used by the cgroup with the given path.
+func (s Source) Cgroup(path string) (CgroupInfo, error) {
+	root, err := s.cgroupRoot()
+	if err != nil {
+		return CgroupInfo{}, err
+	}
+	ifo, err := readCgroup(filepath.Join(root, path))
+	if err != nil {
+		return ifo, err
+	}
+	ifo.Path = filepath.Join("/", path)
+	return ifo, nil
+}
+
+// cgroupRoot returns the directory of the unified hierarchy, either mounted
+// on /sys/fs/cgroup or on /sys/fs/cgroup/unified on hybrid systems.
+func (s Source) cgroupRoot() (string, error) {
+	for _, dir := range []string{cgroupDir, filepath.Join(cgroupDir, "unified")} {
+		dir = s.path(dir)
+		if _, err := os.Stat(filepath.Join(dir, "cgroup.controllers")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", ErrNoCgroup
+}
+
+func readCgroup(dir string) (CgroupInfo, error) {
+	info := CgroupInfo{
+		When: time.Now(),
+	}
+	if err := readCgroupCpu(dir, &info.Cpu); err != nil {
+		return info, err
+	}
+	var err error
+	if info.MemoryCurrent, err = readCgroupValue(dir, "memory.current"); ignoreMissing(err) != nil {
+		return info, err
+	}
+	if info.MemoryMax, err = readCgroupValue(dir, "memory.max"); err != nil {
+		if ignoreMissing(err) != nil {
+			return info, err
+		}
+		info.MemoryMax = -1
+	}
+	if info.PidsCurrent, err = readCgroupValue(dir, "pids.current"); ignoreMissing(err) != nil {
+		return info, err
+	}
+	if info.MemoryStat, err = readCgroupKeys(filepath.Join(dir, "memory.stat")); ignoreMissing(err) != nil {
+		return info, err
+	}
+	if info.Io, err = readCgroupIo(dir); ignoreMissing(err) != nil {
+		return info, err
+	}
+	for _, r := range pressureResources {
+		p, err := readPressure(filepath.Join(dir, r+".pressure"))
+		if err != nil {
+			if errors.Is(err, ErrNoPressure) {
+				continue
+			}
+			return info, err
+		}
+		p.Resource = r
+		info.Pressure = append(info.Pressure, p)
+	}
+	return info, nil
+}
+
+func readCgroupCpu(dir string, cpu *CgroupCpu) error {
+	keys, err := readCgroupKeys(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return err
+	}
+	cpu.Usage = time.Duration(keys["usage_usec"]) * time.Microsecond
+	cpu.User = time.Duration(keys["user_usec"]) * time.Microsecond
+	cpu.System = time.Duration(keys["system_usec"]) * time.Microsecond
+	cpu.Periods = keys["nr_periods"]
+	cpu.Throttled = keys["nr_throttled"]
+	cpu.Wait = time.Duration(keys["throttled_usec"]) * time.Microsecond
+	return nil
+}
+
+func readCgroupIo(dir string) ([]CgroupIo, error) {
+	r, err := os.Open(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		list []CgroupIo
+	)
+	for scan.Scan() {
+		var (
+			fields = strings.Fields(scan.Text())
+			io     CgroupIo
+		)
+		if len(fields) == 0 {
+			continue
+		}
+		major, minor, _ := strings.Cut(fields[0], ":")
+		io.Major, _ = strconv.Atoi(major)
+		io.Minor, _ = strconv.Atoi(minor)
+		for _, f := range fields[1:] {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("io.stat: invalid value %q", f)
+			}
+			switch key {
+			case "rbytes":
+				io.Rbytes = n
+			case "wbytes":
+				io.Wbytes = n
+			case "rios":
+				io.Rios = n
+			case "wios":
+				io.Wios = n
+			case "dbytes":
+				io.Dbytes = n
+			case "dios":
+				io.Dios = n
+			default:
+			}
+		}
+		list = append(list, io)
+	}
+	return list, scan.Err()
+}
+
+// readCgroupValue reads a file holding a single value. The special value
+// "max" is returned as -1.
+func readCgroupValue(dir, file string) (int64, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	buf = bytes.TrimSpace(buf)
+	if string(buf) == "max" {
+		return -1, nil
+	}
+	return strconv.ParseInt(string(buf), 10, 64)
+}
+
+// readCgroupKeys reads a flat keyed file made of lines with a key and its
+// value separated by a space.
+func readCgroupKeys(file string) (map[string]int64, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		keys = make(map[string]int64)
+	)
+	for scan.Scan() {
+		key, value, ok := strings.Cut(scan.Text(), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid value for %s", filepath.Base(file), key)
+		}
+		keys[key] = n
+	}
+	return keys, scan.Err()
+}
+
+func ignoreMissing(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+type CgroupActivity struct {
+	Path       string
+	Cpu        float64
+	ReadBytes  float64
+	WriteBytes float64
+	ReadIos    float64
+	WriteIos   float64
+}
+
+// CgroupRate computes the cpu usage, as a percentage of one cpu, and the io
+// throughput of a cgroup between two samples.
+func CgroupRate(prev, curr CgroupInfo) CgroupActivity {
+	rate := CgroupActivity{
+		Path: curr.Path,
+	}
+	elapsed := curr.When.Sub(prev.When)
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(prev, curr int64) float64 {
+		if curr < prev {
+			return 0
+		}
+		return float64(curr-prev) / elapsed.Seconds()
+	}
+	if curr.Cpu.Usage >= prev.Cpu.Usage {
+		rate.Cpu = float64(curr.Cpu.Usage-prev.Cpu.Usage) * 100 / float64(elapsed)
+	}
+	var ptot, ctot CgroupIo
+	for _, io := range prev.Io {
+		ptot.Rbytes += io.Rbytes
+		ptot.Wbytes += io.Wbytes
+		ptot.Rios += io.Rios
+		ptot.Wios += io.Wios
+	}
+	for _, io := range curr.Io {
+		ctot.Rbytes += io.Rbytes
+		ctot.Wbytes += io.Wbytes
+		ctot.Rios += io.Rios
+		ctot.Wios += io.Wios
+	}
+	rate.ReadBytes = perSec(ptot.Rbytes, ctot.Rbytes)
+	rate.WriteBytes = perSec(ptot.Wbytes, ctot.Wbytes)
+	rate.ReadIos = perSec(ptot.Rios, ctot.Rios)
+	rate.WriteIos = perSec(ptot.Wios, ctot.Wios)
+	return rate
+}
+
+// CgroupRates computes the activity of each cgroup present in both samples.
+func CgroupRates(prev, curr []CgroupInfo) []CgroupActivity {
+	seen := make(map[string]CgroupInfo)
+	for _, c := range prev {
+		seen[c.Path] = c
+	}
+	var list []CgroupActivity
+	for _, c := range curr {
+		p, ok := seen[c.Path]
+		if !ok {
+			continue
+		}
+		list = append(list, CgroupRate(p, c))
+	}
+	return list
+}
+
+// readProcCgroup returns the path of the process in the cgroup v2 hierarchy
+// and the identifier of the container found in any of its hierarchies.
+func readProcCgroup(dir string) (string, string, error) {
+	r, err := os.Open(filepath.Join(dir, procCgroup))
+	if err != nil {
+		return "", "", err
+	}
+	defer r.Close()
+
+	var (
+		scan      = bufio.NewScanner(r)
+		path      string
+		container string
+	)
+	for scan.Scan() {
+		parts := strings.SplitN(scan.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			path = parts[2]
+		}
+		if container == "" {
+			container = ContainerId(parts[2])
+		}
+	}
+	return path, container, scan.Err()
+}
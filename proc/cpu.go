@@ -2,7 +2,9 @@ package proc
 
 import (
 	"bufio"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/midbel/slices"
@@ -22,8 +24,70 @@ type CpuInfo struct {
 	GuestNice int
 }
 
+// Total returns the sum of jiffies spent by the cpu. Guest times are already
+// accounted in User and Nice by the kernel.
+func (c CpuInfo) Total() int {
+	return c.User + c.Nice + c.Sys + c.Idle + c.Iowait + c.Irq + c.SoftIrq + c.Steal
+}
+
+func (c CpuInfo) Busy() int {
+	return c.Total() - c.Idle - c.Iowait
+}
+
 func Cpu() ([]CpuInfo, error) {
-	return readSystemStat(statFile)
+	return system.Cpu()
+}
+
+func (s Source) Cpu() ([]CpuInfo, error) {
+	return readSystemStat(s.path(statFile))
+}
+
+type CpuUsage struct {
+	Ident  string
+	Busy   float64
+	User   float64
+	Sys    float64
+	Iowait float64
+	Steal  float64
+}
+
+// Usage computes the percentages of time spent by the cpu in its different
+// states between two samples.
+func Usage(prev, curr CpuInfo) CpuUsage {
+	usage := CpuUsage{
+		Ident: curr.Ident,
+	}
+	total := float64(curr.Total() - prev.Total())
+	if total <= 0 {
+		return usage
+	}
+	percent := func(diff int) float64 {
+		return float64(diff) * 100 / total
+	}
+	usage.Busy = percent(curr.Busy() - prev.Busy())
+	usage.User = percent((curr.User + curr.Nice) - (prev.User + prev.Nice))
+	usage.Sys = percent((curr.Sys + curr.Irq + curr.SoftIrq) - (prev.Sys + prev.Irq + prev.SoftIrq))
+	usage.Iowait = percent(curr.Iowait - prev.Iowait)
+	usage.Steal = percent(curr.Steal - prev.Steal)
+	return usage
+}
+
+// UsageAll computes the usage of each cpu present in both samples. The
+// aggregate of all cpus is identified by "cpu".
+func UsageAll(prev, curr []CpuInfo) []CpuUsage {
+	seen := make(map[string]CpuInfo)
+	for _, c := range prev {
+		seen[c.Ident] = c
+	}
+	var list []CpuUsage
+	for _, c := range curr {
+		p, ok := seen[c.Ident]
+		if !ok {
+			continue
+		}
+		list = append(list, Usage(p, c))
+	}
+	return list
 }
 
 func readSystemStat(file string) ([]CpuInfo, error) {
@@ -42,10 +106,40 @@ func readSystemStat(file string) ([]CpuInfo, error) {
 		if !strings.HasPrefix(slices.Fst(fields), "cpu") {
 			continue
 		}
-		cpu := CpuInfo{
-			Ident: slices.Fst(fields),
+		cpu, err := parseCpuLine(fields)
+		if err != nil {
+			return nil, err
 		}
 		list = append(list, cpu)
 	}
-	return list, nil
+	return list, scan.Err()
+}
+
+func parseCpuLine(fields []string) (CpuInfo, error) {
+	cpu := CpuInfo{
+		Ident: slices.Fst(fields),
+	}
+	values := []*int{
+		&cpu.User,
+		&cpu.Nice,
+		&cpu.Sys,
+		&cpu.Idle,
+		&cpu.Iowait,
+		&cpu.Irq,
+		&cpu.SoftIrq,
+		&cpu.Steal,
+		&cpu.Guest,
+		&cpu.GuestNice,
+	}
+	for i, str := range slices.Rest(fields) {
+		if i >= len(values) {
+			break
+		}
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			return cpu, fmt.Errorf("%s: invalid value %q", cpu.Ident, str)
+		}
+		*values[i] = n
+	}
+	return cpu, nil
 }
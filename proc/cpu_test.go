@@ -0,0 +1,50 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestCpu(t *testing.T) {
+	list, err := fixtures.Cpu()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CpuInfo{
+		{Ident: "cpu", User: 4705, Nice: 356, Sys: 584, Idle: 3699176, Iowait: 23060, SoftIrq: 277, Steal: 10},
+		{Ident: "cpu0", User: 1393, Nice: 280, Sys: 283, Idle: 1832000, Iowait: 11500, SoftIrq: 170, Steal: 5},
+		{Ident: "cpu1", User: 3312, Nice: 76, Sys: 301, Idle: 1867176, Iowait: 11560, SoftIrq: 107, Steal: 5},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("cpus mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Errorf("%s: cpu mismatched! want %+v, got %+v", want[i].Ident, want[i], list[i])
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	data := []struct {
+		Prev CpuInfo
+		Curr CpuInfo
+		Want CpuUsage
+	}{
+		{
+			Prev: CpuInfo{Ident: "cpu", User: 100, Sys: 100, Idle: 800},
+			Curr: CpuInfo{Ident: "cpu", User: 150, Sys: 120, Idle: 900, Iowait: 20, Steal: 10},
+			Want: CpuUsage{Ident: "cpu", Busy: 40, User: 25, Sys: 10, Iowait: 10, Steal: 5},
+		},
+		{
+			Prev: CpuInfo{Ident: "cpu0", User: 100, Idle: 100},
+			Curr: CpuInfo{Ident: "cpu0", User: 100, Idle: 100},
+			Want: CpuUsage{Ident: "cpu0"},
+		},
+	}
+	for _, d := range data {
+		got := Usage(d.Prev, d.Curr)
+		if got != d.Want {
+			t.Errorf("%s: usage mismatched! want %+v, got %+v", d.Want.Ident, d.Want, got)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package proc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProcessorInfo holds the description of a logical cpu found in
+// /proc/cpuinfo.
+type ProcessorInfo struct {
+	Processor  int
+	Vendor     string
+	Family     string
+	Model      string
+	ModelName  string
+	Stepping   string
+	MHz        float64
+	CacheSize  int64
+	PhysicalId int
+	CoreId     int
+	Siblings   int
+	Cores      int
+	Flags      []string
+	Bogomips   float64
+}
+
+func (p ProcessorInfo) Has(flag string) bool {
+	for _, f := range p.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func Processors() ([]ProcessorInfo, error) {
+	return system.Processors()
+}
+
+func (s Source) Processors() ([]ProcessorInfo, error) {
+	r, err := os.Open(s.path(cpuinfoFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		list []ProcessorInfo
+		curr *ProcessorInfo
+	)
+	scan.Buffer(make([]byte, 0, 4096), 1<<20)
+	for scan.Scan() {
+		field, value, ok := strings.Cut(scan.Text(), ":")
+		if !ok {
+			curr = nil
+			continue
+		}
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+		if field == "processor" {
+			list = append(list, ProcessorInfo{})
+			curr = &list[len(list)-1]
+		}
+		if curr == nil {
+			continue
+		}
+		var err error
+		switch field {
+		case "processor":
+			curr.Processor, err = strconv.Atoi(value)
+		case "vendor_id":
+			curr.Vendor = value
+		case "cpu family":
+			curr.Family = value
+		case "model":
+			curr.Model = value
+		case "model name":
+			curr.ModelName = value
+		case "stepping":
+			curr.Stepping = value
+		case "cpu MHz":
+			curr.MHz, err = strconv.ParseFloat(value, 64)
+		case "cache size":
+			value, _, _ = strings.Cut(value, " ")
+			curr.CacheSize, err = strconv.ParseInt(value, 10, 64)
+		case "physical id":
+			curr.PhysicalId, err = strconv.Atoi(value)
+		case "core id":
+			curr.CoreId, err = strconv.Atoi(value)
+		case "siblings":
+			curr.Siblings, err = strconv.Atoi(value)
+		case "cpu cores":
+			curr.Cores, err = strconv.Atoi(value)
+		case "flags", "Features":
+			curr.Flags = strings.Fields(value)
+		case "bogomips", "BogoMIPS":
+			curr.Bogomips, err = strconv.ParseFloat(value, 64)
+		default:
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid value %q", field, value)
+		}
+	}
+	return list, scan.Err()
+}
+
+// CpuTopology describes the location of a logical cpu and its frequency
+// scaling. Frequencies are given in MHz and are zero when cpufreq is not
+// available.
+type CpuTopology struct {
+	Cpu      int
+	Online   bool
+	Core     int
+	Package  int
+	Die      int
+	Node     int
+	CurFreq  float64
+	MinFreq  float64
+	MaxFreq  float64
+	Governor string
+}
+
+func Topology() ([]CpuTopology, error) {
+	return system.Topology()
+}
+
+func (s Source) Topology() ([]CpuTopology, error) {
+	present, err := s.cpuList("present")
+	if err != nil {
+		return nil, err
+	}
+	online, err := s.OnlineCpus()
+	if err != nil {
+		return nil, err
+	}
+	isOnline := make(map[int]bool)
+	for _, c := range online {
+		isOnline[c] = true
+	}
+	var list []CpuTopology
+	for _, c := range present {
+		var (
+			dir  = s.path(cpuDir, "cpu"+strconv.Itoa(c))
+			topo = CpuTopology{
+				Cpu:    c,
+				Online: isOnline[c],
+			}
+		)
+		topo.Core = readSysInt(dir, "topology/core_id")
+		topo.Package = readSysInt(dir, "topology/physical_package_id")
+		topo.Die = readSysInt(dir, "topology/die_id")
+		topo.Node = cpuNode(dir)
+
+		freq := filepath.Join(dir, "cpufreq")
+		topo.CurFreq, _ = readSysValue(freq, "scaling_cur_freq", 1000)
+		topo.MinFreq, _ = readSysValue(freq, "cpuinfo_min_freq", 1000)
+		topo.MaxFreq, _ = readSysValue(freq, "cpuinfo_max_freq", 1000)
+		topo.Governor = readSysString(freq, "scaling_governor")
+		list = append(list, topo)
+	}
+	return list, nil
+}
+
+func OnlineCpus() ([]int, error) {
+	return system.OnlineCpus()
+}
+
+func (s Source) OnlineCpus() ([]int, error) {
+	return s.cpuList("online")
+}
+
+func OfflineCpus() ([]int, error) {
+	return system.OfflineCpus()
+}
+
+func (s Source) OfflineCpus() ([]int, error) {
+	return s.cpuList("offline")
+}
+
+func (s Source) cpuList(file string) ([]int, error) {
+	buf, err := os.ReadFile(s.path(cpuDir, file))
+	if err != nil {
+		return nil, err
+	}
+	return parseCpuList(strings.TrimSpace(string(buf)))
+}
+
+// parseCpuList parses a list of cpus in the format used by the kernel: a
+// comma separated list of cpu numbers or of ranges (eg: 0-3,8,10-11).
+func parseCpuList(str string) ([]int, error) {
+	var list []int
+	if str == "" {
+		return list, nil
+	}
+	for _, part := range strings.Split(str, ",") {
+		fst, lst, ok := strings.Cut(part, "-")
+		from, err := strconv.Atoi(fst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid cpu list", str)
+		}
+		to := from
+		if ok {
+			if to, err = strconv.Atoi(lst); err != nil {
+				return nil, fmt.Errorf("%s: invalid cpu list", str)
+			}
+		}
+		for i := from; i <= to; i++ {
+			list = append(list, i)
+		}
+	}
+	sort.Ints(list)
+	return list, nil
+}
+
+// cpuNode returns the NUMA node of the cpu from the nodeN link found in its
+// sysfs directory. Systems without NUMA report all cpus on node 0.
+func cpuNode(dir string) int {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	for _, f := range files {
+		str, ok := strings.CutPrefix(f.Name(), "node")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(str); err == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+func readSysInt(dir, file string) int {
+	n, err := readSysValue(dir, file, 1)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return -1
+	}
+	return int(n)
+}
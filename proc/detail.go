@@ -0,0 +1,201 @@
+package proc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// IdSet holds the real, effective, saved set and filesystem identifiers of a
+// process as reported by the Uid and Gid lines of /proc/[pid]/status.
+type IdSet struct {
+	Real      int
+	Effective int
+	Saved     int
+	Fs        int
+}
+
+type ProcDetail struct {
+	ProcInfo
+
+	Exe      string
+	Deleted  bool
+	Cwd      string
+	Root     string
+	Environ  map[string]string
+	LoginUid int
+	Uid      IdSet
+	Gid      IdSet
+
+	Affinity    []int
+	Fds         int // -1 when the fd directory can not be read
+	Limits      []LimitInfo
+	OomScore    int
+	OomScoreAdj int
+}
+
+func Detail(pid int) (ProcDetail, error) {
+	return system.Detail(pid)
+}
+
+// Detail returns the information of a process with its executable, working
+// directory, environment and identifiers. The files that can not be read
+// without privileges are left empty.
+func (s Source) Detail(pid int) (ProcDetail, error) {
+	var (
+		dir    = s.pidDir(pid)
+		detail ProcDetail
+		err    error
+	)
+	boot, err := s.BootTime()
+	if err != nil {
+		return detail, err
+	}
+	if detail.ProcInfo, err = s.readProcess(pid, boot); err != nil {
+		return detail, err
+	}
+	if err = readDetailStatus(dir, &detail); err != nil {
+		return detail, err
+	}
+	if detail.Limits, err = readLimits(dir); err != nil {
+		return detail, err
+	}
+	if detail.OomScore, err = readProcInt(dir, procOomScore); err != nil {
+		return detail, err
+	}
+	if detail.OomScoreAdj, err = readProcInt(dir, procOomScoreAdj); err != nil {
+		return detail, err
+	}
+	detail.Fds = -1
+	if files, err := os.ReadDir(filepath.Join(dir, procFd)); err == nil {
+		detail.Fds = len(files)
+	}
+	exe, err := readProcLink(dir, procExe)
+	if err != nil {
+		return detail, err
+	}
+	detail.Exe, detail.Deleted = strings.CutSuffix(exe, " (deleted)")
+	if detail.Cwd, err = readProcLink(dir, procCwd); err != nil {
+		return detail, err
+	}
+	if detail.Root, err = readProcLink(dir, procRoot); err != nil {
+		return detail, err
+	}
+	if detail.Environ, err = readEnviron(dir); err != nil {
+		return detail, err
+	}
+	if detail.LoginUid, err = readLoginUid(dir); err != nil {
+		return detail, err
+	}
+	return detail, nil
+}
+
+// readProcLink returns the target of a link of /proc/[pid]. Links of kernel
+// threads and of processes of other users are reported as empty.
+func readProcLink(dir, file string) (string, error) {
+	link, err := os.Readlink(filepath.Join(dir, file))
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) || errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return link, nil
+}
+
+func readEnviron(dir string) (map[string]string, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, procEnviron))
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	env := make(map[string]string)
+	for _, str := range bytes.Split(buf, []byte{0}) {
+		if len(str) == 0 {
+			continue
+		}
+		key, value, _ := strings.Cut(string(str), "=")
+		env[key] = value
+	}
+	return env, nil
+}
+
+// readLoginUid returns the login uid of the process or -1 if it is not set.
+func readLoginUid(dir string) (int, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, procLoginuid))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return -1, nil
+		}
+		return -1, err
+	}
+	uid, err := strconv.ParseUint(string(bytes.TrimSpace(buf)), 10, 32)
+	if err != nil {
+		return -1, err
+	}
+	if uid == 1<<32-1 {
+		return -1, nil
+	}
+	return int(uid), nil
+}
+
+// readDetailStatus reads the identifiers and the cpu affinity of the process
+// from /proc/[pid]/status.
+func readDetailStatus(dir string, detail *ProcDetail) error {
+	r, err := os.Open(filepath.Join(dir, procStatus))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		field, value, ok := strings.Cut(scan.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch field {
+		case "Uid":
+			detail.Uid, err = parseIdSet(value)
+		case "Gid":
+			detail.Gid, err = parseIdSet(value)
+		case "Cpus_allowed_list":
+			detail.Affinity, err = parseCpuList(strings.TrimSpace(value))
+		default:
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+	return scan.Err()
+}
+
+func parseIdSet(str string) (IdSet, error) {
+	var (
+		set    IdSet
+		fields = strings.Fields(str)
+		values = make([]int, 4)
+		err    error
+	)
+	if len(fields) != len(values) {
+		return set, fmt.Errorf("invalid ids %q", str)
+	}
+	for i := range values {
+		if values[i], err = strconv.Atoi(fields[i]); err != nil {
+			return set, err
+		}
+	}
+	set.Real = values[0]
+	set.Effective = values[1]
+	set.Saved = values[2]
+	set.Fs = values[3]
+	return set, nil
+}
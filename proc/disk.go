@@ -0,0 +1,207 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sectorSize is the size of the sectors reported in /proc/diskstats,
+// independently of the real sector size of the device.
+const sectorSize = 512
+
+type DiskInfo struct {
+	Major int
+	Minor int
+	Name  string
+
+	Reads        int64
+	ReadsMerged  int64
+	ReadSectors  int64
+	ReadTime     time.Duration
+	Writes       int64
+	WritesMerged int64
+	WriteSectors int64
+	WriteTime    time.Duration
+	InFlight     int64
+	IoTime       time.Duration
+	QueueTime    time.Duration
+
+	Discards       int64
+	DiscardsMerged int64
+	DiscardSectors int64
+	DiscardTime    time.Duration
+	Flushes        int64
+	FlushTime      time.Duration
+
+	When time.Time
+}
+
+func Disks() ([]DiskInfo, error) {
+	return system.Disks()
+}
+
+func (s Source) Disks() ([]DiskInfo, error) {
+	r, err := os.Open(s.path(diskstatsFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		now  = time.Now()
+		list []DiskInfo
+	)
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) < 14 {
+			continue
+		}
+		disk, err := parseDiskLine(fields)
+		if err != nil {
+			return nil, err
+		}
+		disk.When = now
+		list = append(list, disk)
+	}
+	return list, scan.Err()
+}
+
+func parseDiskLine(fields []string) (DiskInfo, error) {
+	var (
+		disk DiskInfo
+		err  error
+	)
+	if disk.Major, err = strconv.Atoi(fields[0]); err != nil {
+		return disk, err
+	}
+	if disk.Minor, err = strconv.Atoi(fields[1]); err != nil {
+		return disk, err
+	}
+	disk.Name = fields[2]
+
+	var (
+		readTime    int64
+		writeTime   int64
+		ioTime      int64
+		queueTime   int64
+		discardTime int64
+		flushTime   int64
+		values      = []*int64{
+			&disk.Reads,
+			&disk.ReadsMerged,
+			&disk.ReadSectors,
+			&readTime,
+			&disk.Writes,
+			&disk.WritesMerged,
+			&disk.WriteSectors,
+			&writeTime,
+			&disk.InFlight,
+			&ioTime,
+			&queueTime,
+			&disk.Discards,
+			&disk.DiscardsMerged,
+			&disk.DiscardSectors,
+			&discardTime,
+			&disk.Flushes,
+			&flushTime,
+		}
+	)
+	// discards and flushes are only available with recent kernels
+	for i, str := range fields[3:] {
+		if i >= len(values) {
+			break
+		}
+		if *values[i], err = strconv.ParseInt(str, 10, 64); err != nil {
+			return disk, fmt.Errorf("%s: invalid value %q", disk.Name, str)
+		}
+	}
+	disk.ReadTime = time.Duration(readTime) * time.Millisecond
+	disk.WriteTime = time.Duration(writeTime) * time.Millisecond
+	disk.IoTime = time.Duration(ioTime) * time.Millisecond
+	disk.QueueTime = time.Duration(queueTime) * time.Millisecond
+	disk.DiscardTime = time.Duration(discardTime) * time.Millisecond
+	disk.FlushTime = time.Duration(flushTime) * time.Millisecond
+	return disk, nil
+}
+
+type DiskActivity struct {
+	Name         string
+	Reads        float64
+	Writes       float64
+	ReadsMerged  float64
+	WritesMerged float64
+	ReadBytes    float64
+	WriteBytes   float64
+	Discards     float64
+	ReadAwait    time.Duration
+	WriteAwait   time.Duration
+	Await        time.Duration
+	QueueSize    float64
+	Util         float64
+}
+
+// DiskRate computes the activity of a block device between two samples in
+// the same way as iostat -x.
+func DiskRate(prev, curr DiskInfo) DiskActivity {
+	rate := DiskActivity{
+		Name: curr.Name,
+	}
+	elapsed := curr.When.Sub(prev.When)
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(prev, curr int64) float64 {
+		if curr < prev {
+			return 0
+		}
+		return float64(curr-prev) / elapsed.Seconds()
+	}
+	await := func(ios int64, prev, curr time.Duration) time.Duration {
+		if ios <= 0 {
+			return 0
+		}
+		return (curr - prev) / time.Duration(ios)
+	}
+	var (
+		reads  = curr.Reads - prev.Reads
+		writes = curr.Writes - prev.Writes
+	)
+	rate.Reads = perSec(prev.Reads, curr.Reads)
+	rate.Writes = perSec(prev.Writes, curr.Writes)
+	rate.ReadsMerged = perSec(prev.ReadsMerged, curr.ReadsMerged)
+	rate.WritesMerged = perSec(prev.WritesMerged, curr.WritesMerged)
+	rate.ReadBytes = perSec(prev.ReadSectors, curr.ReadSectors) * sectorSize
+	rate.WriteBytes = perSec(prev.WriteSectors, curr.WriteSectors) * sectorSize
+	rate.Discards = perSec(prev.Discards, curr.Discards)
+	rate.ReadAwait = await(reads, prev.ReadTime, curr.ReadTime)
+	rate.WriteAwait = await(writes, prev.WriteTime, curr.WriteTime)
+	rate.Await = await(reads+writes, prev.ReadTime+prev.WriteTime, curr.ReadTime+curr.WriteTime)
+	rate.QueueSize = float64(curr.QueueTime-prev.QueueTime) / float64(elapsed)
+	rate.Util = float64(curr.IoTime-prev.IoTime) * 100 / float64(elapsed)
+	if rate.Util > 100 {
+		rate.Util = 100
+	}
+	return rate
+}
+
+// DiskRates computes the activity of each device present in both samples.
+func DiskRates(prev, curr []DiskInfo) []DiskActivity {
+	seen := make(map[string]DiskInfo)
+	for _, d := range prev {
+		seen[d.Name] = d
+	}
+	var list []DiskActivity
+	for _, d := range curr {
+		p, ok := seen[d.Name]
+		if !ok {
+			continue
+		}
+		list = append(list, DiskRate(p, d))
+	}
+	return list
+}
@@ -0,0 +1,357 @@
+package proc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+type FdType int
+
+const (
+	FdOther FdType = iota
+	FdFile
+	FdSocket
+	FdPipe
+	FdAnon
+)
+
+func (f FdType) String() string {
+	switch f {
+	default:
+		return "unknown"
+	case FdFile:
+		return "file"
+	case FdSocket:
+		return "socket"
+	case FdPipe:
+		return "pipe"
+	case FdAnon:
+		return "anon_inode"
+	}
+}
+
+type FdInfo struct {
+	Fd      int
+	Target  string
+	Type    FdType
+	Deleted bool
+	Flags   int
+	Pos     int64
+	MntId   int
+	Dev     uint64
+	Inode   uint64
+}
+
+// Mode returns the access mode of the file descriptor in the format used by
+// lsof: r for read, w for write and u for read and write.
+func (f FdInfo) Mode() string {
+	switch f.Flags & syscall.O_ACCMODE {
+	case syscall.O_RDONLY:
+		return "r"
+	case syscall.O_WRONLY:
+		return "w"
+	default:
+		return "u"
+	}
+}
+
+type FileUser struct {
+	Pid     int
+	Command string
+	User    string
+	FdInfo
+}
+
+func Files(pid int) ([]FdInfo, error) {
+	return system.Files(pid)
+}
+
+// Files returns the list of the file descriptors opened by the given process.
+func (s Source) Files(pid int) ([]FdInfo, error) {
+	dir := s.pidDir(pid)
+	files, err := os.ReadDir(filepath.Join(dir, procFd))
+	if err != nil {
+		return nil, err
+	}
+	var list []FdInfo
+	for _, f := range files {
+		fd, err := strconv.Atoi(f.Name())
+		if err != nil {
+			continue
+		}
+		ifo, err := readFdInfo(dir, fd)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		list = append(list, ifo)
+	}
+	return list, nil
+}
+
+func FilesByPid(pid int) ([]FileUser, error) {
+	return system.FilesByPid(pid)
+}
+
+// FilesByPid returns the file descriptors opened by the given process with
+// the command and the owner of the process.
+func (s Source) FilesByPid(pid int) ([]FileUser, error) {
+	return s.processFiles(pid, func(_ FdInfo) bool { return true })
+}
+
+func OpenFiles() ([]FileUser, error) {
+	return system.OpenFiles()
+}
+
+// OpenFiles returns the file descriptors of all the processes. Processes
+// that can not be inspected are skipped.
+func (s Source) OpenFiles() ([]FileUser, error) {
+	return s.findFiles(func(_ FdInfo) bool { return true })
+}
+
+func FilesByPath(file string) ([]FileUser, error) {
+	return system.FilesByPath(file)
+}
+
+// FilesByPath returns the file descriptors referencing the given file. The
+// path is made absolute and its symlinks resolved to match the targets of the
+// links of /proc/[pid]/fd.
+func (s Source) FilesByPath(file string) ([]FileUser, error) {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	if real, err := filepath.EvalSymlinks(s.path(file)); err == nil {
+		if rel, err := filepath.Rel(s.Root(), real); err == nil {
+			file = filepath.Join("/", rel)
+		}
+	}
+	return s.findFiles(func(ifo FdInfo) bool {
+		return ifo.Type == FdFile && ifo.Target == file
+	})
+}
+
+func FilesByMount(dir string) ([]FileUser, error) {
+	return system.FilesByMount(dir)
+}
+
+// FilesByMount returns the file descriptors referencing a file located on
+// the filesystem mounted on dir.
+func (s Source) FilesByMount(dir string) ([]FileUser, error) {
+	fi, err := os.Stat(s.path(dir))
+	if err != nil {
+		return nil, err
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil, fmt.Errorf("%s: device not available", dir)
+	}
+	return s.findFiles(func(ifo FdInfo) bool {
+		return ifo.Type == FdFile && ifo.Dev == uint64(st.Dev)
+	})
+}
+
+func FilesByPort(proto string, port uint16) ([]FileUser, error) {
+	return system.FilesByPort(proto, port)
+}
+
+// FilesByPort returns the file descriptors of the sockets bound to the given
+// local port. proto can be tcp, udp, udplite, raw, icmp or empty to match any
+// of them.
+func (s Source) FilesByPort(proto string, port uint16) ([]FileUser, error) {
+	return s.filesBySocket(proto, func(c ConnInfo) bool {
+		return c.Local.Port() == port
+	})
+}
+
+func FilesByProto(proto string) ([]FileUser, error) {
+	return system.FilesByProto(proto)
+}
+
+// FilesByProto returns the file descriptors of the sockets of the given
+// protocol whatever their port. An empty proto matches any protocol.
+func (s Source) FilesByProto(proto string) ([]FileUser, error) {
+	return s.filesBySocket(proto, func(_ ConnInfo) bool { return true })
+}
+
+func (s Source) filesBySocket(proto string, keep func(ConnInfo) bool) ([]FileUser, error) {
+	var (
+		conns []ConnInfo
+		err   error
+	)
+	switch strings.ToLower(proto) {
+	case "tcp":
+		conns, err = s.Tcp()
+	case "udp":
+		conns, err = s.Udp()
+	case "udplite":
+		conns, err = s.UdpLite()
+	case "raw":
+		conns, err = s.Raw()
+	case "icmp":
+		conns, err = s.Icmp()
+	case "":
+		conns, err = s.Netstat()
+		for _, get := range []func() ([]ConnInfo, error){s.UdpLite, s.Raw, s.Icmp} {
+			if err != nil {
+				break
+			}
+			var list []ConnInfo
+			list, err = get()
+			conns = append(conns, list...)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported protocol", proto)
+	}
+	if err != nil {
+		return nil, err
+	}
+	inodes := make(map[uint64]struct{})
+	for _, c := range conns {
+		if c.Inode != 0 && keep(c) {
+			inodes[c.Inode] = struct{}{}
+		}
+	}
+	return s.findFiles(func(ifo FdInfo) bool {
+		if ifo.Type != FdSocket {
+			return false
+		}
+		_, ok := inodes[ifo.Inode]
+		return ok
+	})
+}
+
+func (s Source) findFiles(keep func(FdInfo) bool) ([]FileUser, error) {
+	files, err := os.ReadDir(s.path(procDir))
+	if err != nil {
+		return nil, err
+	}
+	var list []FileUser
+	for _, f := range files {
+		pid, err := strconv.Atoi(f.Name())
+		if err != nil || !f.IsDir() {
+			continue
+		}
+		res, err := s.processFiles(pid, keep)
+		if err != nil {
+			continue
+		}
+		list = append(list, res...)
+	}
+	return list, nil
+}
+
+func (s Source) processFiles(pid int, keep func(FdInfo) bool) ([]FileUser, error) {
+	fds, err := s.Files(pid)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dir  = s.pidDir(pid)
+		comm string
+		who  string
+		list []FileUser
+	)
+	for _, ifo := range fds {
+		if !keep(ifo) {
+			continue
+		}
+		if comm == "" {
+			comm, _ = readComm(dir)
+			who = readOwner(dir)
+		}
+		list = append(list, FileUser{
+			Pid:     pid,
+			Command: comm,
+			User:    who,
+			FdInfo:  ifo,
+		})
+	}
+	return list, nil
+}
+
+func readOwner(dir string) string {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return ""
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	uid := strconv.FormatUint(uint64(st.Uid), 10)
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username
+	}
+	return uid
+}
+
+func readFdInfo(dir string, fd int) (FdInfo, error) {
+	ifo := FdInfo{
+		Fd: fd,
+	}
+	file := filepath.Join(dir, procFd, strconv.Itoa(fd))
+	link, err := os.Readlink(file)
+	if err != nil {
+		return ifo, err
+	}
+	ifo.Target, ifo.Deleted = strings.CutSuffix(link, " (deleted)")
+	switch {
+	case strings.HasPrefix(link, "/"):
+		ifo.Type = FdFile
+	case strings.HasPrefix(link, "socket:"):
+		ifo.Type = FdSocket
+	case strings.HasPrefix(link, "pipe:"):
+		ifo.Type = FdPipe
+	case strings.HasPrefix(link, "anon_inode:"):
+		ifo.Type = FdAnon
+	default:
+		ifo.Type = FdOther
+	}
+	if fi, err := os.Stat(file); err == nil {
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+			ifo.Dev = uint64(st.Dev)
+			ifo.Inode = st.Ino
+		}
+	}
+
+	r, err := os.Open(filepath.Join(dir, procFdinfo, strconv.Itoa(fd)))
+	if err != nil {
+		return ifo, err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		field, value, ok := strings.Cut(scan.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch field {
+		case "pos":
+			ifo.Pos, err = strconv.ParseInt(value, 10, 64)
+		case "flags":
+			var flags int64
+			flags, err = strconv.ParseInt(value, 8, 64)
+			ifo.Flags = int(flags)
+		case "mnt_id":
+			ifo.MntId, err = strconv.Atoi(value)
+		default:
+		}
+		if err != nil {
+			return ifo, fmt.Errorf("%s: invalid value %q", field, value)
+		}
+	}
+	return ifo, scan.Err()
+}
@@ -8,26 +8,119 @@ import (
 	"strings"
 )
 
+// MemInfo summarizes the usage of the memory or of the swap. Values are in
+// kB as reported by the kernel.
 type MemInfo struct {
-	Total   int64
-	Free    int64
-	Shared  int64
-	Cached  int64
-	Buffers int64
+	Total     int64
+	Free      int64
+	Available int64
+	Shared    int64
+	Cached    int64
+	Buffers   int64
 }
 
+// Used returns the memory in use computed in the same way as procps free:
+// the memory not available for starting new applications.
 func (m MemInfo) Used() int64 {
-	return m.Total - m.Free - m.Buffers - m.Cached
+	return m.Total - m.Available
+}
+
+// BuffCache returns the memory used by the kernel buffers, the page cache
+// and the reclaimable slab.
+func (m MemInfo) BuffCache() int64 {
+	return m.Buffers + m.Cached
+}
+
+// MemStat holds the content of /proc/meminfo. Sizes are in kB and the
+// HugePages fields are counts of pages. Raw contains every field of the file
+// including the ones without a typed field.
+type MemStat struct {
+	MemTotal       int64
+	MemFree        int64
+	MemAvailable   int64
+	Buffers        int64
+	Cached         int64
+	SwapCached     int64
+	Active         int64
+	Inactive       int64
+	Unevictable    int64
+	Mlocked        int64
+	SwapTotal      int64
+	SwapFree       int64
+	Dirty          int64
+	Writeback      int64
+	AnonPages      int64
+	Mapped         int64
+	Shmem          int64
+	KReclaimable   int64
+	Slab           int64
+	SReclaimable   int64
+	SUnreclaim     int64
+	KernelStack    int64
+	PageTables     int64
+	CommitLimit    int64
+	CommittedAS    int64
+	VmallocTotal   int64
+	VmallocUsed    int64
+	AnonHugePages  int64
+	ShmemHugePages int64
+	FileHugePages  int64
+	HugePagesTotal int64
+	HugePagesFree  int64
+	HugePagesRsvd  int64
+	HugePagesSurp  int64
+	HugePageSize   int64
+	Hugetlb        int64
+
+	Raw map[string]int64
+}
+
+func (m MemStat) Mem() MemInfo {
+	mem := MemInfo{
+		Total:     m.MemTotal,
+		Free:      m.MemFree,
+		Available: m.MemAvailable,
+		Shared:    m.Shmem,
+		Buffers:   m.Buffers,
+		Cached:    m.Cached + m.SReclaimable,
+	}
+	if _, ok := m.Raw["MemAvailable"]; !ok {
+		mem.Available = mem.Free + mem.BuffCache()
+	}
+	return mem
+}
+
+func (m MemStat) Swap() MemInfo {
+	return MemInfo{
+		Total:     m.SwapTotal,
+		Free:      m.SwapFree,
+		Available: m.SwapFree,
+	}
 }
 
 func Free() (MemInfo, MemInfo, error) {
-	var (
-		mem  MemInfo
-		swap MemInfo
-	)
-	r, err := os.Open(memFile)
+	return system.Free()
+}
+
+func (s Source) Free() (MemInfo, MemInfo, error) {
+	stat, err := s.Memory()
 	if err != nil {
-		return mem, swap, err
+		return MemInfo{}, MemInfo{}, err
+	}
+	return stat.Mem(), stat.Swap(), nil
+}
+
+func Memory() (MemStat, error) {
+	return system.Memory()
+}
+
+func (s Source) Memory() (MemStat, error) {
+	stat := MemStat{
+		Raw: make(map[string]int64),
+	}
+	r, err := os.Open(s.path(memFile))
+	if err != nil {
+		return stat, err
 	}
 	defer r.Close()
 
@@ -35,31 +128,60 @@ func Free() (MemInfo, MemInfo, error) {
 	for scan.Scan() {
 		field, value, ok := strings.Cut(scan.Text(), ":")
 		if !ok {
-			return mem, swap, fmt.Errorf("missing : in line")
+			return stat, fmt.Errorf("missing : in line")
 		}
 		value, _, _ = strings.Cut(strings.TrimSpace(value), " ")
 
 		val, err := strconv.ParseInt(value, 0, 64)
 		if err != nil {
-			return mem, swap, err
-		}
-		switch strings.ToLower(field) {
-		case "memtotal":
-			mem.Total = val
-		case "memfree":
-			mem.Free = val
-		case "swaptotal":
-			swap.Total = val
-		case "swapfree":
-			swap.Free = val
-		case "shmem":
-			mem.Shared = val
-		case "buffers":
-			mem.Buffers = val
-		case "cached", "srreclaimable":
-			mem.Cached += val
-		default:
+			return stat, err
 		}
+		stat.Raw[field] = val
+	}
+	if err := scan.Err(); err != nil {
+		return stat, err
+	}
+
+	fields := map[string]*int64{
+		"MemTotal":        &stat.MemTotal,
+		"MemFree":         &stat.MemFree,
+		"MemAvailable":    &stat.MemAvailable,
+		"Buffers":         &stat.Buffers,
+		"Cached":          &stat.Cached,
+		"SwapCached":      &stat.SwapCached,
+		"Active":          &stat.Active,
+		"Inactive":        &stat.Inactive,
+		"Unevictable":     &stat.Unevictable,
+		"Mlocked":         &stat.Mlocked,
+		"SwapTotal":       &stat.SwapTotal,
+		"SwapFree":        &stat.SwapFree,
+		"Dirty":           &stat.Dirty,
+		"Writeback":       &stat.Writeback,
+		"AnonPages":       &stat.AnonPages,
+		"Mapped":          &stat.Mapped,
+		"Shmem":           &stat.Shmem,
+		"KReclaimable":    &stat.KReclaimable,
+		"Slab":            &stat.Slab,
+		"SReclaimable":    &stat.SReclaimable,
+		"SUnreclaim":      &stat.SUnreclaim,
+		"KernelStack":     &stat.KernelStack,
+		"PageTables":      &stat.PageTables,
+		"CommitLimit":     &stat.CommitLimit,
+		"Committed_AS":    &stat.CommittedAS,
+		"VmallocTotal":    &stat.VmallocTotal,
+		"VmallocUsed":     &stat.VmallocUsed,
+		"AnonHugePages":   &stat.AnonHugePages,
+		"ShmemHugePages":  &stat.ShmemHugePages,
+		"FileHugePages":   &stat.FileHugePages,
+		"HugePages_Total": &stat.HugePagesTotal,
+		"HugePages_Free":  &stat.HugePagesFree,
+		"HugePages_Rsvd":  &stat.HugePagesRsvd,
+		"HugePages_Surp":  &stat.HugePagesSurp,
+		"Hugepagesize":    &stat.HugePageSize,
+		"Hugetlb":         &stat.Hugetlb,
+	}
+	for field, ptr := range fields {
+		*ptr = stat.Raw[field]
 	}
-	return mem, swap, scan.Err()
+	return stat, nil
 }
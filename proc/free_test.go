@@ -0,0 +1,96 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	stat, err := fixtures.Memory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		Field string
+		Want  int64
+		Got   int64
+	}{
+		{Field: "MemTotal", Want: 16303428, Got: stat.MemTotal},
+		{Field: "MemAvailable", Want: 10518120, Got: stat.MemAvailable},
+		{Field: "SReclaimable", Want: 842016, Got: stat.SReclaimable},
+		{Field: "Committed_AS", Want: 15734412, Got: stat.CommittedAS},
+		{Field: "VmallocTotal", Want: 34359738367, Got: stat.VmallocTotal},
+		{Field: "HugePages_Total", Want: 4, Got: stat.HugePagesTotal},
+		{Field: "HugePages_Rsvd", Want: 1, Got: stat.HugePagesRsvd},
+		{Field: "Hugepagesize", Want: 2048, Got: stat.HugePageSize},
+	}
+	for _, d := range data {
+		if d.Got != d.Want {
+			t.Errorf("%s: value mismatched! want %d, got %d", d.Field, d.Want, d.Got)
+		}
+		if raw := stat.Raw[d.Field]; raw != d.Want {
+			t.Errorf("%s: raw value mismatched! want %d, got %d", d.Field, d.Want, raw)
+		}
+	}
+	if _, ok := stat.Raw["NFS_Unstable"]; !ok {
+		t.Errorf("NFS_Unstable: field without typed value missing from raw")
+	}
+}
+
+func TestFree(t *testing.T) {
+	mem, swap, err := fixtures.Free()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MemInfo{
+		Total:     16303428,
+		Free:      2117348,
+		Available: 10518120,
+		Shared:    512736,
+		Cached:    7412856 + 842016,
+		Buffers:   654320,
+	}
+	if mem != want {
+		t.Errorf("memory mismatched! want %+v, got %+v", want, mem)
+	}
+	if used := mem.Used(); used != 16303428-10518120 {
+		t.Errorf("used memory mismatched! want %d, got %d", 16303428-10518120, used)
+	}
+	if buff := mem.BuffCache(); buff != 654320+7412856+842016 {
+		t.Errorf("buff/cache mismatched! want %d, got %d", 654320+7412856+842016, buff)
+	}
+	want = MemInfo{
+		Total:     2097148,
+		Free:      2045948,
+		Available: 2045948,
+	}
+	if swap != want {
+		t.Errorf("swap mismatched! want %+v, got %+v", want, swap)
+	}
+	if used := swap.Used(); used != 51200 {
+		t.Errorf("used swap mismatched! want %d, got %d", 51200, used)
+	}
+}
+
+func TestMemAvailableMissing(t *testing.T) {
+	stat := MemStat{
+		MemTotal:     1000,
+		MemFree:      200,
+		Buffers:      50,
+		Cached:       300,
+		SReclaimable: 100,
+		Raw: map[string]int64{
+			"MemTotal":     1000,
+			"MemFree":      200,
+			"Buffers":      50,
+			"Cached":       300,
+			"SReclaimable": 100,
+		},
+	}
+	mem := stat.Mem()
+	if mem.Available != 650 {
+		t.Errorf("available memory mismatched! want %d, got %d", 650, mem.Available)
+	}
+	if mem.Used() != 350 {
+		t.Errorf("used memory mismatched! want %d, got %d", 350, mem.Used())
+	}
+}
@@ -0,0 +1,95 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/midbel/slices"
+)
+
+type KernelStat struct {
+	Ctxt     int64
+	Intr     int64
+	SoftIrq  int64
+	Forks    int64
+	Running  int
+	Blocked  int
+	BootTime time.Time
+	When     time.Time
+}
+
+func Kernel() (KernelStat, error) {
+	return system.Kernel()
+}
+
+func (s Source) Kernel() (KernelStat, error) {
+	var stat KernelStat
+
+	r, err := os.Open(s.path(statFile))
+	if err != nil {
+		return stat, err
+	}
+	defer r.Close()
+
+	stat.When = time.Now()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseInt(slices.Snd(fields), 10, 64)
+		if err != nil {
+			return stat, fmt.Errorf("%s: invalid value %q", slices.Fst(fields), slices.Snd(fields))
+		}
+		switch slices.Fst(fields) {
+		case "ctxt":
+			stat.Ctxt = value
+		case "intr":
+			stat.Intr = value
+		case "softirq":
+			stat.SoftIrq = value
+		case "processes":
+			stat.Forks = value
+		case "procs_running":
+			stat.Running = int(value)
+		case "procs_blocked":
+			stat.Blocked = int(value)
+		case "btime":
+			stat.BootTime = time.Unix(value, 0)
+		default:
+		}
+	}
+	return stat, scan.Err()
+}
+
+type KernelRate struct {
+	Ctxt    float64
+	Intr    float64
+	SoftIrq float64
+	Forks   float64
+}
+
+// KernelRates computes the per second rates of the kernel counters between
+// two samples.
+func KernelRates(prev, curr KernelStat) KernelRate {
+	var rate KernelRate
+
+	elapsed := curr.When.Sub(prev.When).Seconds()
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(diff int64) float64 {
+		return float64(diff) / elapsed
+	}
+	rate.Ctxt = perSec(curr.Ctxt - prev.Ctxt)
+	rate.Intr = perSec(curr.Intr - prev.Intr)
+	rate.SoftIrq = perSec(curr.SoftIrq - prev.SoftIrq)
+	rate.Forks = perSec(curr.Forks - prev.Forks)
+	return rate
+}
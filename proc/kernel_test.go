@@ -0,0 +1,24 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestKernel(t *testing.T) {
+	stat, err := fixtures.Kernel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Ctxt != 1990473 {
+		t.Errorf("ctxt mismatched! want %d, got %d", 1990473, stat.Ctxt)
+	}
+	if stat.Forks != 2915 {
+		t.Errorf("forks mismatched! want %d, got %d", 2915, stat.Forks)
+	}
+	if stat.Running != 2 || stat.Blocked != 1 {
+		t.Errorf("procs mismatched! want 2/1, got %d/%d", stat.Running, stat.Blocked)
+	}
+	if stat.BootTime.Unix() != 1062191376 {
+		t.Errorf("boot time mismatched! want %d, got %d", 1062191376, stat.BootTime.Unix())
+	}
+}
@@ -0,0 +1,161 @@
+package proc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type SchedPolicy int
+
+const (
+	SchedOther SchedPolicy = iota
+	SchedFifo
+	SchedRR
+	SchedBatch
+	_
+	SchedIdle
+	SchedDeadline
+)
+
+func (s SchedPolicy) String() string {
+	switch s {
+	case SchedOther:
+		return "other"
+	case SchedFifo:
+		return "fifo"
+	case SchedRR:
+		return "rr"
+	case SchedBatch:
+		return "batch"
+	case SchedIdle:
+		return "idle"
+	case SchedDeadline:
+		return "deadline"
+	default:
+		return "unknown"
+	}
+}
+
+// Unlimited is the value of a limit without restriction.
+const Unlimited = -1
+
+// limitResources maps the descriptions of /proc/[pid]/limits to the names
+// of the resources used by setrlimit.
+var limitResources = map[string]string{
+	"Max cpu time":          "cpu",
+	"Max file size":         "fsize",
+	"Max data size":         "data",
+	"Max stack size":        "stack",
+	"Max core file size":    "core",
+	"Max resident set":      "rss",
+	"Max processes":         "nproc",
+	"Max open files":        "nofile",
+	"Max locked memory":     "memlock",
+	"Max address space":     "as",
+	"Max file locks":        "locks",
+	"Max pending signals":   "sigpending",
+	"Max msgqueue size":     "msgqueue",
+	"Max nice priority":     "nice",
+	"Max realtime priority": "rtprio",
+	"Max realtime timeout":  "rttime",
+}
+
+type LimitInfo struct {
+	Resource string
+	Desc     string
+	Soft     int64
+	Hard     int64
+	Unit     string
+}
+
+func Limits(pid int) ([]LimitInfo, error) {
+	return system.Limits(pid)
+}
+
+// Limits returns the resource limits of a process. Limits without
+// restriction are set to Unlimited.
+func (s Source) Limits(pid int) ([]LimitInfo, error) {
+	return readLimits(s.pidDir(pid))
+}
+
+// Limit returns the limit of the given resource from the list.
+func Limit(list []LimitInfo, resource string) (LimitInfo, bool) {
+	for _, i := range list {
+		if i.Resource == resource {
+			return i, true
+		}
+	}
+	return LimitInfo{}, false
+}
+
+// readLimits parses /proc/[pid]/limits. The descriptions of the limits
+// contain spaces so the columns are located from the positions of the
+// headers.
+func readLimits(dir string) ([]LimitInfo, error) {
+	r, err := os.Open(filepath.Join(dir, procLimits))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	if !scan.Scan() {
+		return nil, scan.Err()
+	}
+	var (
+		header = scan.Text()
+		soft   = strings.Index(header, "Soft Limit")
+		hard   = strings.Index(header, "Hard Limit")
+		units  = strings.Index(header, "Units")
+		list   []LimitInfo
+	)
+	if soft < 0 || hard < 0 || units < 0 {
+		return nil, fmt.Errorf("%s: malformed limits file", dir)
+	}
+	column := func(line string, from, to int) string {
+		if from >= len(line) {
+			return ""
+		}
+		if to < 0 || to > len(line) {
+			to = len(line)
+		}
+		return strings.TrimSpace(line[from:to])
+	}
+	for scan.Scan() {
+		var (
+			line  = scan.Text()
+			limit LimitInfo
+		)
+		limit.Desc = column(line, 0, soft)
+		limit.Resource = limitResources[limit.Desc]
+		limit.Unit = column(line, units, -1)
+		if limit.Soft, err = parseLimit(column(line, soft, hard)); err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+		if limit.Hard, err = parseLimit(column(line, hard, units)); err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+		list = append(list, limit)
+	}
+	return list, scan.Err()
+}
+
+func parseLimit(str string) (int64, error) {
+	if str == "unlimited" {
+		return Unlimited, nil
+	}
+	return strconv.ParseInt(str, 10, 64)
+}
+
+func readProcInt(dir, file string) (int, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(bytes.TrimSpace(buf)))
+}
@@ -0,0 +1,31 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	list, err := fixtures.Limits(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LimitInfo{
+		{Resource: "cpu", Desc: "Max cpu time", Soft: Unlimited, Hard: Unlimited, Unit: "seconds"},
+		{Resource: "stack", Desc: "Max stack size", Soft: 8388608, Hard: Unlimited, Unit: "bytes"},
+		{Resource: "nproc", Desc: "Max processes", Soft: 63445, Hard: 63445, Unit: "processes"},
+		{Resource: "nofile", Desc: "Max open files", Soft: 1024, Hard: 524288, Unit: "files"},
+		{Resource: "nice", Desc: "Max nice priority", Soft: 0, Hard: 0},
+		{Resource: "rttime", Desc: "Max realtime timeout", Soft: Unlimited, Hard: Unlimited, Unit: "us"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("limits mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Errorf("%s: limit mismatched! want %+v, got %+v", want[i].Resource, want[i], list[i])
+		}
+	}
+	if _, ok := Limit(list, "nofile"); !ok {
+		t.Errorf("nofile limit not found")
+	}
+}
@@ -7,7 +7,11 @@ import (
 )
 
 func LoadAvg() ([]float64, error) {
-	buf, err := os.ReadFile(loadavgFile)
+	return system.LoadAvg()
+}
+
+func (s Source) LoadAvg() ([]float64, error) {
+	buf, err := os.ReadFile(s.path(loadavgFile))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,226 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var pseudoFs = map[string]struct{}{
+	"autofs":      {},
+	"binfmt_misc": {},
+	"bpf":         {},
+	"cgroup":      {},
+	"cgroup2":     {},
+	"configfs":    {},
+	"debugfs":     {},
+	"devpts":      {},
+	"efivarfs":    {},
+	"fusectl":     {},
+	"hugetlbfs":   {},
+	"mqueue":      {},
+	"nsfs":        {},
+	"proc":        {},
+	"pstore":      {},
+	"rpc_pipefs":  {},
+	"securityfs":  {},
+	"selinuxfs":   {},
+	"sysfs":       {},
+	"tracefs":     {},
+}
+
+type MountInfo struct {
+	Id           int
+	Parent       int
+	Major        int
+	Minor        int
+	Root         string
+	Point        string
+	Options      []string
+	Optional     []string
+	Type         string
+	Source       string
+	SuperOptions []string
+}
+
+// Pseudo reports whether the filesystem is a virtual filesystem without
+// storage behind it.
+func (m MountInfo) Pseudo() bool {
+	_, ok := pseudoFs[m.Type]
+	return ok
+}
+
+func (m MountInfo) ReadOnly() bool {
+	for _, o := range m.Options {
+		if o == "ro" {
+			return true
+		}
+	}
+	return false
+}
+
+func Mounts() ([]MountInfo, error) {
+	return system.Mounts()
+}
+
+// Mounts returns the filesystems mounted in the mount namespace of the init
+// process of the system.
+func (s Source) Mounts() ([]MountInfo, error) {
+	r, err := os.Open(s.path(mountinfoFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		list []MountInfo
+	)
+	for scan.Scan() {
+		m, err := parseMountInfo(scan.Text())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return list, scan.Err()
+}
+
+func parseMountInfo(line string) (MountInfo, error) {
+	var (
+		mount      MountInfo
+		head, tail = cutFields(line, " - ")
+		err        error
+	)
+	if len(head) < 6 || len(tail) < 3 {
+		return mount, fmt.Errorf("malformed mountinfo line")
+	}
+	if mount.Id, err = strconv.Atoi(head[0]); err != nil {
+		return mount, err
+	}
+	if mount.Parent, err = strconv.Atoi(head[1]); err != nil {
+		return mount, err
+	}
+	major, minor, _ := strings.Cut(head[2], ":")
+	if mount.Major, err = strconv.Atoi(major); err != nil {
+		return mount, err
+	}
+	if mount.Minor, err = strconv.Atoi(minor); err != nil {
+		return mount, err
+	}
+	mount.Root = unescapeMount(head[3])
+	mount.Point = unescapeMount(head[4])
+	mount.Options = strings.Split(head[5], ",")
+	mount.Optional = head[6:]
+
+	mount.Type = tail[0]
+	mount.Source = unescapeMount(tail[1])
+	mount.SuperOptions = strings.Split(tail[2], ",")
+	return mount, nil
+}
+
+func cutFields(line, sep string) ([]string, []string) {
+	before, after, _ := strings.Cut(line, sep)
+	return strings.Fields(before), strings.Fields(after)
+}
+
+// unescapeMount decodes the octal sequences used by the kernel to escape
+// spaces, tabs, newlines and backslashes in the paths of mountinfo.
+func unescapeMount(str string) string {
+	if !strings.Contains(str, "\\") {
+		return str
+	}
+	var buf strings.Builder
+	for i := 0; i < len(str); i++ {
+		if str[i] == '\\' && i+3 < len(str) {
+			if n, err := strconv.ParseUint(str[i+1:i+4], 8, 8); err == nil {
+				buf.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		buf.WriteByte(str[i])
+	}
+	return buf.String()
+}
+
+type FsInfo struct {
+	MountInfo
+	BlockSize int64
+	Size      int64
+	Free      int64
+	Avail     int64
+	Files     int64
+	FilesFree int64
+}
+
+func (f FsInfo) Used() int64 {
+	return f.Size - f.Free
+}
+
+// UsedPercent returns the percentage of the space used by unprivileged users
+// in the same way as df.
+func (f FsInfo) UsedPercent() float64 {
+	total := f.Used() + f.Avail
+	if total <= 0 {
+		return 0
+	}
+	return float64(f.Used()) * 100 / float64(total)
+}
+
+func (f FsInfo) FilesUsed() int64 {
+	return f.Files - f.FilesFree
+}
+
+func (f FsInfo) FilesPercent() float64 {
+	if f.Files <= 0 {
+		return 0
+	}
+	return float64(f.FilesUsed()) * 100 / float64(f.Files)
+}
+
+func Statfs(m MountInfo) (FsInfo, error) {
+	return system.Statfs(m)
+}
+
+func (s Source) Statfs(m MountInfo) (FsInfo, error) {
+	info := FsInfo{
+		MountInfo: m,
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(s.path(m.Point), &st); err != nil {
+		return info, err
+	}
+	info.BlockSize = int64(st.Frsize)
+	info.Size = int64(st.Blocks) * info.BlockSize
+	info.Free = int64(st.Bfree) * info.BlockSize
+	info.Avail = int64(st.Bavail) * info.BlockSize
+	info.Files = int64(st.Files)
+	info.FilesFree = int64(st.Ffree)
+	return info, nil
+}
+
+func Filesystems() ([]FsInfo, error) {
+	return system.Filesystems()
+}
+
+// Filesystems returns the capacity of the mounted filesystems. Filesystems
+// that can not be queried are skipped.
+func (s Source) Filesystems() ([]FsInfo, error) {
+	mounts, err := s.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	var list []FsInfo
+	for _, m := range mounts {
+		fs, err := s.Statfs(m)
+		if err != nil {
+			continue
+		}
+		list = append(list, fs)
+	}
+	return list, nil
+}
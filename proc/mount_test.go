@@ -0,0 +1,100 @@
+package proc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMounts(t *testing.T) {
+	list, err := fixtures.Mounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []MountInfo{
+		{
+			Id:           22,
+			Parent:       1,
+			Major:        8,
+			Minor:        1,
+			Root:         "/",
+			Point:        "/",
+			Options:      []string{"rw", "relatime"},
+			Optional:     []string{"shared:1"},
+			Type:         "ext4",
+			Source:       "/dev/sda1",
+			SuperOptions: []string{"rw", "errors=remount-ro"},
+		},
+		{
+			Id:           35,
+			Parent:       22,
+			Major:        0,
+			Minor:        32,
+			Root:         "/",
+			Point:        "/sys",
+			Options:      []string{"rw", "nosuid", "nodev", "noexec", "relatime"},
+			Optional:     []string{"shared:7"},
+			Type:         "sysfs",
+			Source:       "sysfs",
+			SuperOptions: []string{"rw"},
+		},
+		{
+			Id:           60,
+			Parent:       22,
+			Major:        8,
+			Minor:        17,
+			Root:         "/data",
+			Point:        "/mnt/my disk",
+			Options:      []string{"ro", "relatime"},
+			Optional:     []string{"shared:30", "master:2"},
+			Type:         "vfat",
+			Source:       "/dev/sdb1",
+			SuperOptions: []string{"ro", "fmask=0022"},
+		},
+		{
+			Id:           61,
+			Parent:       22,
+			Major:        0,
+			Minor:        50,
+			Root:         "/",
+			Point:        "/run/user",
+			Options:      []string{"rw", "nosuid"},
+			Optional:     []string{},
+			Type:         "tmpfs",
+			Source:       "tmpfs",
+			SuperOptions: []string{"rw", "size=100k"},
+		},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("mounts mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(list[i], want[i]) {
+			t.Errorf("%s: mount mismatched! want %+v, got %+v", want[i].Point, want[i], list[i])
+		}
+	}
+	if !list[1].Pseudo() || list[0].Pseudo() {
+		t.Errorf("pseudo filesystem not detected")
+	}
+	if !list[2].ReadOnly() || list[0].ReadOnly() {
+		t.Errorf("read only filesystem not detected")
+	}
+}
+
+func TestUnescapeMount(t *testing.T) {
+	data := []struct {
+		Input string
+		Want  string
+	}{
+		{Input: "/mnt/data", Want: "/mnt/data"},
+		{Input: `/mnt/my\040disk`, Want: "/mnt/my disk"},
+		{Input: `/mnt/tab\011here`, Want: "/mnt/tab\there"},
+		{Input: `/mnt/back\134slash`, Want: `/mnt/back\slash`},
+		{Input: `/mnt/end\040`, Want: "/mnt/end "},
+		{Input: `/mnt/bad\09`, Want: `/mnt/bad\09`},
+	}
+	for _, d := range data {
+		if got := unescapeMount(d.Input); got != d.Want {
+			t.Errorf("%s: path mismatched! want %q, got %q", d.Input, d.Want, got)
+		}
+	}
+}
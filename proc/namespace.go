@@ -0,0 +1,173 @@
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type NsKind string
+
+const (
+	NsPid    NsKind = "pid"
+	NsNet    NsKind = "net"
+	NsMnt    NsKind = "mnt"
+	NsUts    NsKind = "uts"
+	NsIpc    NsKind = "ipc"
+	NsUser   NsKind = "user"
+	NsCgroup NsKind = "cgroup"
+)
+
+// Namespaces holds the inode numbers identifying the namespaces of a
+// process. A zero value means that the namespace could not be read.
+type Namespaces struct {
+	Pid    uint64
+	Net    uint64
+	Mnt    uint64
+	Uts    uint64
+	Ipc    uint64
+	User   uint64
+	Cgroup uint64
+}
+
+func (n Namespaces) Get(kind NsKind) uint64 {
+	switch kind {
+	case NsPid:
+		return n.Pid
+	case NsNet:
+		return n.Net
+	case NsMnt:
+		return n.Mnt
+	case NsUts:
+		return n.Uts
+	case NsIpc:
+		return n.Ipc
+	case NsUser:
+		return n.User
+	case NsCgroup:
+		return n.Cgroup
+	default:
+		return 0
+	}
+}
+
+// GroupByNamespace clusters the processes sharing the same namespace of the
+// given kind. Processes whose namespace is unknown are grouped under 0.
+func GroupByNamespace(list []ProcInfo, kind NsKind) map[uint64][]ProcInfo {
+	groups := make(map[uint64][]ProcInfo)
+	for _, p := range list {
+		ns := p.Ns.Get(kind)
+		groups[ns] = append(groups[ns], p)
+	}
+	return groups
+}
+
+// GroupByContainer clusters the processes running in the same container.
+// Processes not running in a container are grouped under the empty string.
+func GroupByContainer(list []ProcInfo) map[string][]ProcInfo {
+	groups := make(map[string][]ProcInfo)
+	for _, p := range list {
+		groups[p.Container] = append(groups[p.Container], p)
+	}
+	return groups
+}
+
+// containerPrefixes maps the prefixes used by the container engines to name
+// the systemd scopes of the containers to the name of the engines.
+var containerPrefixes = []struct {
+	Prefix  string
+	Runtime string
+}{
+	{Prefix: "docker-", Runtime: "docker"},
+	{Prefix: "cri-containerd-", Runtime: "containerd"},
+	{Prefix: "crio-", Runtime: "cri-o"},
+	{Prefix: "libpod-", Runtime: "podman"},
+}
+
+// ContainerId extracts the identifier of a container from a cgroup path as
+// created by docker, containerd, cri-o, podman or the kubelet. It returns an
+// empty string if the path does not belong to a container.
+func ContainerId(path string) string {
+	_, id := ContainerRuntime(path)
+	return id
+}
+
+// ContainerRuntime extracts the name of the container engine and the
+// identifier of the container from a cgroup path. Containers managed by the
+// kubelet are reported as kubernetes.
+func ContainerRuntime(path string) (string, string) {
+	var (
+		parts   = strings.Split(path, "/")
+		runtime string
+		id      string
+	)
+	for i := len(parts) - 1; i >= 0 && id == ""; i-- {
+		name := strings.TrimSuffix(parts[i], ".scope")
+		if strings.HasPrefix(name, "libpod-conmon-") {
+			continue
+		}
+		runtime = ""
+		for _, c := range containerPrefixes {
+			if str, ok := strings.CutPrefix(name, c.Prefix); ok {
+				name, runtime = str, c.Runtime
+				break
+			}
+		}
+		if !isContainerId(name) {
+			continue
+		}
+		id = name
+		if runtime == "" && i > 0 {
+			runtime = parts[i-1]
+		}
+	}
+	if id == "" {
+		return "", ""
+	}
+	if strings.Contains(path, "kubepods") {
+		runtime = "kubernetes"
+	}
+	return runtime, id
+}
+
+func isContainerId(str string) bool {
+	if len(str) != 64 {
+		return false
+	}
+	for _, c := range str {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// readNamespaces reads the links of /proc/[pid]/ns. Namespaces of processes
+// owned by other users can not be read without privileges and are left to
+// zero.
+func readNamespaces(dir string) Namespaces {
+	var (
+		ns  Namespaces
+		get = func(kind NsKind) uint64 {
+			link, err := os.Readlink(filepath.Join(dir, procNs, string(kind)))
+			if err != nil {
+				return 0
+			}
+			str, ok := strings.CutPrefix(link, string(kind)+":[")
+			if !ok {
+				return 0
+			}
+			inode, _ := strconv.ParseUint(strings.TrimSuffix(str, "]"), 10, 64)
+			return inode
+		}
+	)
+	ns.Pid = get(NsPid)
+	ns.Net = get(NsNet)
+	ns.Mnt = get(NsMnt)
+	ns.Uts = get(NsUts)
+	ns.Ipc = get(NsIpc)
+	ns.User = get(NsUser)
+	ns.Cgroup = get(NsCgroup)
+	return ns
+}
@@ -0,0 +1,41 @@
+package proc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContainerRuntime(t *testing.T) {
+	const id = "4f1c8b7e2a9d3c6f0b5e8a1d7c4f2b9e6a3d0c8f5b2e9a6d3c0f7b4e1a8d5c2f"
+
+	data := []struct {
+		Path    string
+		Runtime string
+		Id      string
+	}{
+		{Path: "/system.slice/docker-" + id + ".scope", Runtime: "docker", Id: id},
+		{Path: "/docker/" + id, Runtime: "docker", Id: id},
+		{Path: "/system.slice/cri-containerd-" + id + ".scope", Runtime: "containerd", Id: id},
+		{Path: "/system.slice/crio-" + id + ".scope", Runtime: "cri-o", Id: id},
+		{Path: "/machine.slice/libpod-" + id + ".scope/container", Runtime: "podman", Id: id},
+		{Path: "/machine.slice/libpod-conmon-" + id + ".scope"},
+		{Path: "/kubepods/besteffort/pod8c3f0a52-7d1e-4b6a-9f2c-1e5d8a7b3c4f/" + id, Runtime: "kubernetes", Id: id},
+		{Path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8c3f0a52_7d1e_4b6a_9f2c_1e5d8a7b3c4f.slice/cri-containerd-" + id + ".scope", Runtime: "kubernetes", Id: id},
+		{Path: "/"},
+		{Path: "/user.slice/user-1000.slice/session-2.scope"},
+		{Path: "/system.slice/docker-" + id[:63] + ".scope"},
+		{Path: "/docker/" + strings.ToUpper(id)},
+	}
+	for _, d := range data {
+		runtime, got := ContainerRuntime(d.Path)
+		if runtime != d.Runtime {
+			t.Errorf("%s: runtime mismatched! want %q, got %q", d.Path, d.Runtime, runtime)
+		}
+		if got != d.Id {
+			t.Errorf("%s: id mismatched! want %q, got %q", d.Path, d.Id, got)
+		}
+		if got := ContainerId(d.Path); got != d.Id {
+			t.Errorf("%s: container id mismatched! want %q, got %q", d.Path, d.Id, got)
+		}
+	}
+}
@@ -3,13 +3,16 @@ package proc
 import (
 	"bufio"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/netip"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/midbel/slices"
 )
@@ -60,20 +63,171 @@ func (c ConnState) String() string {
 	}
 }
 
+type ConnTimer byte
+
+const (
+	TimerOff ConnTimer = iota
+	TimerOn
+	TimerKeepAlive
+	TimerTimeWait
+	TimerProbe
+)
+
+func (c ConnTimer) String() string {
+	switch c {
+	default:
+		return "unknown"
+	case TimerOff:
+		return "off"
+	case TimerOn:
+		return "on"
+	case TimerKeepAlive:
+		return "keepalive"
+	case TimerTimeWait:
+		return "timewait"
+	case TimerProbe:
+		return "probe"
+	}
+}
+
 type ConnInfo struct {
 	Proto  string
 	Local  netip.AddrPort
 	Remote netip.AddrPort
 	State  ConnState
 	User   string
+	Uid    int
+	Inode  uint64
+
+	TxQueue     int64
+	RxQueue     int64
+	Timer       ConnTimer
+	Expires     time.Duration
+	Retransmits int
+	Timeout     int
+
+	Pid     int
+	Command string
+}
+
+// SocketIndex maps the inode of each socket to the pid of the process holding
+// it. Building the index requires scanning the file descriptors of every
+// process, so one index should be shared between the tables of a sample.
+type SocketIndex struct {
+	src  Source
+	pids map[uint64]int
+}
+
+func Sockets() (SocketIndex, error) {
+	return system.Sockets()
+}
+
+// Sockets builds the index by scanning the links of /proc/[pid]/fd. Processes
+// that can not be inspected are skipped.
+func (s Source) Sockets() (SocketIndex, error) {
+	index := SocketIndex{
+		src:  s,
+		pids: make(map[uint64]int),
+	}
+	files, err := os.ReadDir(s.path(procDir))
+	if err != nil {
+		return index, err
+	}
+	for _, f := range files {
+		pid, err := strconv.Atoi(f.Name())
+		if err != nil || !f.IsDir() {
+			continue
+		}
+		dir := filepath.Join(s.pidDir(pid), procFd)
+		fds, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(dir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			inode, ok := socketInode(link)
+			if !ok {
+				continue
+			}
+			if _, ok := index.pids[inode]; !ok {
+				index.pids[inode] = pid
+			}
+		}
+	}
+	return index, nil
+}
+
+// Owner returns the pid and the command of the process holding the socket.
+func (x SocketIndex) Owner(inode uint64) (int, string, bool) {
+	pid, ok := x.pids[inode]
+	if !ok || inode == 0 {
+		return 0, "", false
+	}
+	comm, _ := readComm(x.src.pidDir(pid))
+	return pid, comm, true
+}
+
+// SetOwners sets the pid and the command of the process owning each socket
+// of the list. Sockets whose owner can not be found are left untouched.
+func (x SocketIndex) SetOwners(list []ConnInfo) {
+	for i := range list {
+		if pid, comm, ok := x.Owner(list[i].Inode); ok {
+			list[i].Pid, list[i].Command = pid, comm
+		}
+	}
+}
+
+// SetUnixOwners sets the pid and the command of the process owning each unix
+// socket of the list.
+func (x SocketIndex) SetUnixOwners(list []UnixInfo) {
+	for i := range list {
+		if pid, comm, ok := x.Owner(list[i].Inode); ok {
+			list[i].Pid, list[i].Command = pid, comm
+		}
+	}
+}
+
+// Owners sets the pid and the command of the process owning each socket of
+// the list. Sockets whose owner can not be found are left untouched.
+func Owners(list []ConnInfo) error {
+	return system.Owners(list)
+}
+
+func (s Source) Owners(list []ConnInfo) error {
+	index, err := s.Sockets()
+	if err != nil {
+		return err
+	}
+	index.SetOwners(list)
+	return nil
+}
+
+func socketInode(link string) (uint64, bool) {
+	str, ok := strings.CutPrefix(link, "socket:[")
+	if !ok {
+		return 0, false
+	}
+	str, ok = strings.CutSuffix(str, "]")
+	if !ok {
+		return 0, false
+	}
+	inode, err := strconv.ParseUint(str, 10, 64)
+	return inode, err == nil
 }
 
 func Netstat() ([]ConnInfo, error) {
-	list, err := Tcp()
+	return system.Netstat()
+}
+
+func (s Source) Netstat() ([]ConnInfo, error) {
+	list, err := s.Tcp()
 	if err != nil {
 		return nil, err
 	}
-	rest, err := Udp()
+	rest, err := s.Udp()
 	if err != nil {
 		return nil, err
 	}
@@ -81,11 +235,15 @@ func Netstat() ([]ConnInfo, error) {
 }
 
 func Tcp() ([]ConnInfo, error) {
-	conns, err := readSocketTable(tcpFile)
+	return system.Tcp()
+}
+
+func (s Source) Tcp() ([]ConnInfo, error) {
+	conns, err := readSocketTable(s.path(tcpFile))
 	if err != nil {
 		return nil, err
 	}
-	rest, err := readSocketTable(tcp6File)
+	rest, err := readSocketTable(s.path(tcp6File))
 	if err != nil {
 		return nil, err
 	}
@@ -93,65 +251,60 @@ func Tcp() ([]ConnInfo, error) {
 }
 
 func Udp() ([]ConnInfo, error) {
-	conns, err := readSocketTable(udpFile)
+	return system.Udp()
+}
+
+func (s Source) Udp() ([]ConnInfo, error) {
+	conns, err := readSocketTable(s.path(udpFile))
 	if err != nil {
 		return nil, err
 	}
-	rest, err := readSocketTable(udp6File)
+	rest, err := readSocketTable(s.path(udp6File))
 	if err != nil {
 		return nil, err
 	}
 	return append(conns, rest...), nil
 }
 
-type RouteInfo struct {
-	Interface string
-	Mask      netip.Prefix
-	Network   netip.Addr
-	Gateway   netip.Addr
+func Raw() ([]ConnInfo, error) {
+	return system.Raw()
 }
 
-func Routes() ([]RouteInfo, error) {
-	r, err := os.Open(routeFile)
-	if err != nil {
-		return nil, err
-	}
-	defer r.Close()
+func (s Source) Raw() ([]ConnInfo, error) {
+	return s.readSocketTables(rawFile, raw6File)
+}
 
-	getAddr := func(str string) (netip.Addr, error) {
-		tmp, err := hex.DecodeString(str)
-		if err != nil {
-			return netip.Addr{}, err
-		}
-		ip, ok := netip.AddrFromSlice(slices.Reverse(tmp))
-		if !ok {
-			return ip, fmt.Errorf("invalid address")
-		}
-		return ip, nil
-	}
+func Icmp() ([]ConnInfo, error) {
+	return system.Icmp()
+}
 
-	var (
-		list []RouteInfo
-		scan = bufio.NewScanner(r)
-	)
-	scan.Scan()
-	for scan.Scan() {
-		var (
-			line   = strings.TrimSpace(scan.Text())
-			fields = strings.Fields(line)
-			route  RouteInfo
-			err    error
-		)
-		route.Interface = slices.At(fields, 0)
-		if route.Network, err = getAddr(slices.At(fields, 1)); err != nil {
-			return nil, err
-		}
-		if route.Gateway, err = getAddr(slices.At(fields, 1)); err != nil {
+func (s Source) Icmp() ([]ConnInfo, error) {
+	return s.readSocketTables(icmpFile, icmp6File)
+}
+
+func UdpLite() ([]ConnInfo, error) {
+	return system.UdpLite()
+}
+
+func (s Source) UdpLite() ([]ConnInfo, error) {
+	return s.readSocketTables(udpliteFile, udplite6File)
+}
+
+// readSocketTables reads and merges the given socket tables. Tables not
+// available on the running kernel are skipped.
+func (s Source) readSocketTables(files ...string) ([]ConnInfo, error) {
+	var list []ConnInfo
+	for _, f := range files {
+		conns, err := readSocketTable(s.path(f))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
 			return nil, err
 		}
-		list = append(list, route)
+		list = append(list, conns...)
 	}
-	return list, scan.Err()
+	return list, nil
 }
 
 func readSocketTable(file string) ([]ConnInfo, error) {
@@ -169,17 +322,21 @@ func readSocketTable(file string) ([]ConnInfo, error) {
 		if err != nil {
 			return netip.AddrPort{}, err
 		}
-		tmp, err := hex.DecodeString(addr)
+		ip, err := parseHexAddr(addr)
 		if err != nil {
 			return netip.AddrPort{}, err
 		}
-		tmp = slices.Reverse(tmp)
-		ip, ok := netip.AddrFromSlice(tmp)
-		if !ok {
-			return netip.AddrPort{}, fmt.Errorf("invalid address")
-		}
 		return netip.AddrPortFrom(ip, uint16(port)), nil
 	}
+	getPair := func(str string) (int64, int64, error) {
+		fst, snd, _ := strings.Cut(str, ":")
+		x, err := strconv.ParseInt(fst, 16, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		y, err := strconv.ParseInt(snd, 16, 64)
+		return x, y, err
+	}
 
 	var (
 		scan  = bufio.NewScanner(r)
@@ -209,13 +366,57 @@ func readSocketTable(file string) ([]ConnInfo, error) {
 		}
 		conn.State = ConnState(state)
 
-		who, err := user.LookupId(slices.At(fields, 7))
+		if conn.TxQueue, conn.RxQueue, err = getPair(slices.At(fields, 4)); err != nil {
+			return nil, err
+		}
+		timer, when, err := getPair(slices.At(fields, 5))
 		if err != nil {
 			return nil, err
 		}
-		conn.User = who.Username
+		conn.Timer = ConnTimer(timer)
+		conn.Expires = time.Duration(when) * (time.Second / clockTicks)
+
+		retrans, err := strconv.ParseInt(slices.At(fields, 6), 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		conn.Retransmits = int(retrans)
+		if conn.Uid, err = strconv.Atoi(slices.At(fields, 7)); err != nil {
+			return nil, err
+		}
+		conn.User = slices.At(fields, 7)
+		if who, err := user.LookupId(conn.User); err == nil {
+			conn.User = who.Username
+		}
+		if conn.Timeout, err = strconv.Atoi(slices.At(fields, 8)); err != nil {
+			return nil, err
+		}
+		if conn.Inode, err = strconv.ParseUint(slices.At(fields, 9), 10, 64); err != nil {
+			return nil, err
+		}
 
 		list = append(list, conn)
 	}
 	return list, scan.Err()
 }
+
+// parseHexAddr decodes the hexadecimal representation of an address used in
+// the files of /proc/net. Addresses are written as a sequence of 32 bits words
+// in host byte order.
+func parseHexAddr(str string) (netip.Addr, error) {
+	buf, err := hex.DecodeString(str)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if len(buf)%4 != 0 {
+		return netip.Addr{}, fmt.Errorf("%s: invalid address", str)
+	}
+	for i := 0; i < len(buf); i += 4 {
+		buf[i], buf[i+1], buf[i+2], buf[i+3] = buf[i+3], buf[i+2], buf[i+1], buf[i]
+	}
+	ip, ok := netip.AddrFromSlice(buf)
+	if !ok {
+		return ip, fmt.Errorf("%s: invalid address", str)
+	}
+	return ip, nil
+}
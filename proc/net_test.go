@@ -0,0 +1,91 @@
+package proc
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestTcp(t *testing.T) {
+	list, err := fixtures.Tcp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ConnInfo{
+		{
+			Proto:  "tcp",
+			Local:  netip.MustParseAddrPort("127.0.0.1:631"),
+			Remote: netip.MustParseAddrPort("0.0.0.0:0"),
+			State:  StateListen,
+			User:   "0",
+			Inode:  1234,
+		},
+		{
+			Proto:       "tcp",
+			Local:       netip.MustParseAddrPort("10.0.2.15:22"),
+			Remote:      netip.MustParseAddrPort("10.0.2.2:50000"),
+			State:       StateEstablished,
+			Uid:         1000,
+			Inode:       5678,
+			TxQueue:     42,
+			RxQueue:     16,
+			Timer:       TimerKeepAlive,
+			Expires:     3 * time.Second,
+			Retransmits: 3,
+		},
+		{
+			Proto:       "tcp",
+			Local:       netip.MustParseAddrPort("10.0.2.15:22"),
+			Remote:      netip.MustParseAddrPort("10.0.2.3:54321"),
+			State:       StateEstablished,
+			Uid:         1000,
+			Inode:       5679,
+			Timer:       TimerOn,
+			Expires:     time.Second,
+			Retransmits: 16,
+		},
+		{
+			Proto:  "tcp6",
+			Local:  netip.MustParseAddrPort("[::1]:80"),
+			Remote: netip.MustParseAddrPort("[::]:0"),
+			State:  StateListen,
+			Inode:  9012,
+		},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("connections mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		got := list[i]
+		// user names depend on the accounts of the running system
+		got.User, want[i].User = "", ""
+		if got != want[i] {
+			t.Errorf("%d: connection mismatched! want %+v, got %+v", i, want[i], got)
+		}
+	}
+}
+
+func TestParseHexAddr(t *testing.T) {
+	data := []struct {
+		Input string
+		Want  string
+	}{
+		{Input: "0100007F", Want: "127.0.0.1"},
+		{Input: "0F02000A", Want: "10.0.2.15"},
+		{Input: "00000000000000000000000001000000", Want: "::1"},
+		{Input: "B80D0120000000000000000001000000", Want: "2001:db8::1"},
+	}
+	for _, d := range data {
+		got, err := parseHexAddr(d.Input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if got.String() != d.Want {
+			t.Errorf("%s: address mismatched! want %s, got %s", d.Input, d.Want, got)
+		}
+	}
+	if _, err := parseHexAddr("0100007"); err == nil {
+		t.Errorf("invalid address should fail")
+	}
+}
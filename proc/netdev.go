@@ -0,0 +1,149 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type IfaceInfo struct {
+	Name string
+
+	RxBytes      int64
+	RxPackets    int64
+	RxErrors     int64
+	RxDrops      int64
+	RxFifo       int64
+	RxFrame      int64
+	RxCompressed int64
+	RxMulticast  int64
+
+	TxBytes      int64
+	TxPackets    int64
+	TxErrors     int64
+	TxDrops      int64
+	TxFifo       int64
+	TxColls      int64
+	TxCarrier    int64
+	TxCompressed int64
+
+	When time.Time
+}
+
+func Interfaces() ([]IfaceInfo, error) {
+	return system.Interfaces()
+}
+
+func (s Source) Interfaces() ([]IfaceInfo, error) {
+	r, err := os.Open(s.path(netdevFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		now  = time.Now()
+		list []IfaceInfo
+	)
+	for scan.Scan() {
+		name, rest, ok := strings.Cut(scan.Text(), ":")
+		if !ok {
+			continue
+		}
+		ifo := IfaceInfo{
+			Name: strings.TrimSpace(name),
+			When: now,
+		}
+		values := []*int64{
+			&ifo.RxBytes,
+			&ifo.RxPackets,
+			&ifo.RxErrors,
+			&ifo.RxDrops,
+			&ifo.RxFifo,
+			&ifo.RxFrame,
+			&ifo.RxCompressed,
+			&ifo.RxMulticast,
+			&ifo.TxBytes,
+			&ifo.TxPackets,
+			&ifo.TxErrors,
+			&ifo.TxDrops,
+			&ifo.TxFifo,
+			&ifo.TxColls,
+			&ifo.TxCarrier,
+			&ifo.TxCompressed,
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < len(values) {
+			return nil, fmt.Errorf("%s: not enough fields", ifo.Name)
+		}
+		for i := range values {
+			*values[i], err = strconv.ParseInt(fields[i], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid value %q", ifo.Name, fields[i])
+			}
+		}
+		list = append(list, ifo)
+	}
+	return list, scan.Err()
+}
+
+type IfaceRate struct {
+	Name      string
+	RxBytes   float64
+	RxPackets float64
+	RxErrors  float64
+	RxDrops   float64
+	TxBytes   float64
+	TxPackets float64
+	TxErrors  float64
+	TxDrops   float64
+}
+
+// InterfaceRate computes the per second rates of the counters of an
+// interface between two samples.
+func InterfaceRate(prev, curr IfaceInfo) IfaceRate {
+	rate := IfaceRate{
+		Name: curr.Name,
+	}
+	elapsed := curr.When.Sub(prev.When).Seconds()
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(prev, curr int64) float64 {
+		if curr < prev {
+			return 0
+		}
+		return float64(curr-prev) / elapsed
+	}
+	rate.RxBytes = perSec(prev.RxBytes, curr.RxBytes)
+	rate.RxPackets = perSec(prev.RxPackets, curr.RxPackets)
+	rate.RxErrors = perSec(prev.RxErrors, curr.RxErrors)
+	rate.RxDrops = perSec(prev.RxDrops, curr.RxDrops)
+	rate.TxBytes = perSec(prev.TxBytes, curr.TxBytes)
+	rate.TxPackets = perSec(prev.TxPackets, curr.TxPackets)
+	rate.TxErrors = perSec(prev.TxErrors, curr.TxErrors)
+	rate.TxDrops = perSec(prev.TxDrops, curr.TxDrops)
+	return rate
+}
+
+// InterfaceRates computes the rates of each interface present in both
+// samples.
+func InterfaceRates(prev, curr []IfaceInfo) []IfaceRate {
+	seen := make(map[string]IfaceInfo)
+	for _, i := range prev {
+		seen[i.Name] = i
+	}
+	var list []IfaceRate
+	for _, i := range curr {
+		p, ok := seen[i.Name]
+		if !ok {
+			continue
+		}
+		list = append(list, InterfaceRate(p, i))
+	}
+	return list
+}
@@ -0,0 +1,111 @@
+package proc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/midbel/slices"
+)
+
+// ErrNoPressure is returned when the kernel does not support pressure stall
+// information or when it has been disabled.
+var ErrNoPressure = errors.New("pressure stall information not available")
+
+var pressureResources = []string{"cpu", "memory", "io"}
+
+type PressureStat struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  time.Duration
+}
+
+type PressureInfo struct {
+	Resource string
+	Some     PressureStat
+	Full     PressureStat
+}
+
+func Pressure() ([]PressureInfo, error) {
+	return system.Pressure()
+}
+
+// Pressure returns the pressure stall information of the cpu, memory and io
+// resources.
+func (s Source) Pressure() ([]PressureInfo, error) {
+	var list []PressureInfo
+	for _, r := range pressureResources {
+		ifo, err := readPressure(s.path(pressureDir, r))
+		if err != nil {
+			return nil, err
+		}
+		ifo.Resource = r
+		list = append(list, ifo)
+	}
+	return list, nil
+}
+
+func readPressure(file string) (PressureInfo, error) {
+	var info PressureInfo
+
+	r, err := os.Open(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP) {
+			err = ErrNoPressure
+		}
+		return info, err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		var (
+			fields = strings.Fields(scan.Text())
+			stat   PressureStat
+		)
+		for _, f := range slices.Rest(fields) {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				return info, fmt.Errorf("%s: malformed field %q", file, f)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				stat.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stat.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stat.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				var total int64
+				total, err = strconv.ParseInt(value, 10, 64)
+				stat.Total = time.Duration(total) * time.Microsecond
+			default:
+			}
+			if err != nil {
+				return info, fmt.Errorf("%s: invalid value %q", file, f)
+			}
+		}
+		switch slices.Fst(fields) {
+		case "some":
+			info.Some = stat
+		case "full":
+			info.Full = stat
+		default:
+		}
+	}
+	if err := scan.Err(); err != nil {
+		if errors.Is(err, syscall.EOPNOTSUPP) {
+			err = ErrNoPressure
+		}
+		return info, err
+	}
+	return info, nil
+}
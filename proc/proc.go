@@ -2,24 +2,102 @@ package proc
 
 import (
 	"path/filepath"
+	"strconv"
 )
 
 const (
-	proc        = "/proc"
-	procStatus  = "status"
-	procCmdline = "cmdline"
+	procDir         = "proc"
+	procStatus      = "status"
+	procCmdline     = "cmdline"
+	procComm        = "comm"
+	procStat        = "stat"
+	procStatm       = "statm"
+	procFd          = "fd"
+	procFdinfo      = "fdinfo"
+	procCgroup      = "cgroup"
+	procNs          = "ns"
+	procExe         = "exe"
+	procCwd         = "cwd"
+	procRoot        = "root"
+	procEnviron     = "environ"
+	procLoginuid    = "loginuid"
+	procLimits      = "limits"
+	procOomScore    = "oom_score"
+	procOomScoreAdj = "oom_score_adj"
+	procSmaps       = "smaps"
+	procSmapsRollup = "smaps_rollup"
+	procMaps        = "maps"
 )
 
 var (
-	uptimeFile  = filepath.Join(proc, "uptime")
-	memFile     = filepath.Join(proc, "meminfo")
-	loadavgFile = filepath.Join(proc, "loadavg")
-	statFile    = filepath.Join(proc, "stat")
-	tcpFile     = filepath.Join(proc, "net", "tcp")
-	tcp6File    = filepath.Join(proc, "net", "tcp6")
-	udpFile     = filepath.Join(proc, "net", "udp")
-	udp6File    = filepath.Join(proc, "net", "udp6")
-	routeFile   = filepath.Join(proc, "net", "route")
-	wtmpFile    = "/var/log/wtmp"
-	utmpFile    = "/var/run/utmp"
+	uptimeFile       = filepath.Join(procDir, "uptime")
+	memFile          = filepath.Join(procDir, "meminfo")
+	loadavgFile      = filepath.Join(procDir, "loadavg")
+	statFile         = filepath.Join(procDir, "stat")
+	tcpFile          = filepath.Join(procDir, "net", "tcp")
+	tcp6File         = filepath.Join(procDir, "net", "tcp6")
+	udpFile          = filepath.Join(procDir, "net", "udp")
+	udp6File         = filepath.Join(procDir, "net", "udp6")
+	udpliteFile      = filepath.Join(procDir, "net", "udplite")
+	udplite6File     = filepath.Join(procDir, "net", "udplite6")
+	rawFile          = filepath.Join(procDir, "net", "raw")
+	raw6File         = filepath.Join(procDir, "net", "raw6")
+	icmpFile         = filepath.Join(procDir, "net", "icmp")
+	icmp6File        = filepath.Join(procDir, "net", "icmp6")
+	unixFile         = filepath.Join(procDir, "net", "unix")
+	routeFile        = filepath.Join(procDir, "net", "route")
+	route6File       = filepath.Join(procDir, "net", "ipv6_route")
+	netdevFile       = filepath.Join(procDir, "net", "dev")
+	arpFile          = filepath.Join(procDir, "net", "arp")
+	diskstatsFile    = filepath.Join(procDir, "diskstats")
+	mountinfoFile    = filepath.Join(procDir, "1", "mountinfo")
+	pressureDir      = filepath.Join(procDir, "pressure")
+	cgroupDir        = filepath.Join("sys", "fs", "cgroup")
+	vmstatFile       = filepath.Join(procDir, "vmstat")
+	thermalDir       = filepath.Join("sys", "class", "thermal")
+	hwmonDir         = filepath.Join("sys", "class", "hwmon")
+	cpuDir           = filepath.Join("sys", "devices", "system", "cpu")
+	cpuinfoFile      = filepath.Join(procDir, "cpuinfo")
+	versionFile      = filepath.Join(procDir, "version")
+	osreleaseFile    = filepath.Join(procDir, "sys", "kernel", "osrelease")
+	hostnameFile     = filepath.Join(procDir, "sys", "kernel", "hostname")
+	domainnameFile   = filepath.Join(procDir, "sys", "kernel", "domainname")
+	machineIdFile    = filepath.Join("etc", "machine-id")
+	etcHostnameFile  = filepath.Join("etc", "hostname")
+	osReleaseFile    = filepath.Join("etc", "os-release")
+	osReleaseLibFile = filepath.Join("usr", "lib", "os-release")
+	dmiVendorFile    = filepath.Join("sys", "class", "dmi", "id", "sys_vendor")
+	dmiProductFile   = filepath.Join("sys", "class", "dmi", "id", "product_name")
+	wtmpFile         = filepath.Join("var", "log", "wtmp")
+	utmpFile         = filepath.Join("var", "run", "utmp")
 )
+
+// Source gives access to the files of a system mounted under a root
+// directory. The package level functions use a Source rooted at / to read
+// the live system.
+type Source struct {
+	root string
+}
+
+var system = NewSource("/")
+
+func NewSource(root string) Source {
+	return Source{
+		root: root,
+	}
+}
+
+func (s Source) Root() string {
+	if s.root == "" {
+		return "/"
+	}
+	return s.root
+}
+
+func (s Source) path(file ...string) string {
+	return filepath.Join(s.Root(), filepath.Join(file...))
+}
+
+func (s Source) pidDir(pid int) string {
+	return s.path(procDir, strconv.Itoa(pid))
+}
@@ -0,0 +1,8 @@
+package proc
+
+import (
+	"path/filepath"
+)
+
+// fixtures reads the files of a fake system stored under testdata.
+var fixtures = NewSource(filepath.Join("testdata", "system"))
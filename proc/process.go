@@ -3,19 +3,40 @@ package proc
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/midbel/shlex"
 	"github.com/midbel/slices"
 )
 
+// clockTicks is the number of clock ticks per second (USER_HZ) used by the
+// kernel to report times in /proc/[pid]/stat.
+const clockTicks = 100
+
+// statFields is the number of fields of /proc/[pid]/stat, after the command
+// name, used to fill ProcInfo.
+const statFields = 20
+
+// statRtPriority and statPolicy are the indexes of the rt_priority and policy
+// fields of /proc/[pid]/stat after the command name.
+const (
+	statRtPriority = 37
+	statPolicy     = 38
+)
+
 type ProcInfo struct {
 	Pid      int
+	PPid     int
+	Pgrp     int
+	Session  int
+	Tty      int
 	Cmd      string
 	Args     []string
 	Status   rune
@@ -23,10 +44,84 @@ type ProcInfo struct {
 	Group    string
 	Nice     int
 	Priority int
+	Threads  int
+	Cgroup   string
+
+	Container string
+	Ns        Namespaces
+
+	Policy           SchedPolicy
+	RtPriority       int
+	VoluntaryCtxt    int64
+	NonVoluntaryCtxt int64
+
+	Utime  time.Duration
+	Stime  time.Duration
+	Start  time.Time
+	MinFlt int64
+	MajFlt int64
+	Vsz    int64
+	Rss    int64
+	Shared int64
+
+	When time.Time
+}
+
+// Terminal returns the name of the controlling terminal of the process or an
+// empty string if the process has none.
+func (p ProcInfo) Terminal() string {
+	if p.Tty == 0 {
+		return ""
+	}
+	var (
+		major = (p.Tty >> 8) & 0xfff
+		minor = (p.Tty & 0xff) | ((p.Tty >> 12) & 0xfff00)
+	)
+	switch {
+	case major == 4 && minor < 64:
+		return fmt.Sprintf("tty%d", minor)
+	case major == 4:
+		return fmt.Sprintf("ttyS%d", minor-64)
+	case major >= 136 && major <= 143:
+		return fmt.Sprintf("pts/%d", (major-136)*256+minor)
+	default:
+		return fmt.Sprintf("%d:%d", major, minor)
+	}
+}
+
+// CpuTime returns the total cpu time used by the process.
+func (p ProcInfo) CpuTime() time.Duration {
+	return p.Utime + p.Stime
+}
+
+// ProcessUsage returns the percentage of cpu used by a process between two
+// samples. If prev is not a previous sample of the same process, the usage
+// is computed since the start of the process.
+func ProcessUsage(prev, curr ProcInfo) float64 {
+	var (
+		since = prev.When
+		used  = prev.CpuTime()
+	)
+	if prev.Pid != curr.Pid || !prev.Start.Equal(curr.Start) {
+		since, used = curr.Start, 0
+	}
+	elapsed := curr.When.Sub(since)
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(curr.CpuTime()-used) * 100 / float64(elapsed)
 }
 
 func Process() ([]ProcInfo, error) {
-	files, err := os.ReadDir(proc)
+	return system.Process()
+}
+
+func (s Source) Process() ([]ProcInfo, error) {
+	files, err := os.ReadDir(s.path(procDir))
+	if err != nil {
+		return nil, err
+	}
+	boot, err := s.BootTime()
 	if err != nil {
 		return nil, err
 	}
@@ -35,14 +130,15 @@ func Process() ([]ProcInfo, error) {
 		if !f.IsDir() {
 			continue
 		}
-		if _, err := strconv.Atoi(f.Name()); err != nil {
+		pid, err := strconv.Atoi(f.Name())
+		if err != nil {
 			continue
 		}
-		ifo, err := readProcInfo(filepath.Join(proc, f.Name()))
+		ifo, err := s.readProcess(pid, boot)
 		if err != nil {
-			return nil, err
-		}
-		if ifo.Args, err = readCmdline(filepath.Join(proc, f.Name())); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
 			return nil, err
 		}
 		list = append(list, ifo)
@@ -50,19 +146,122 @@ func Process() ([]ProcInfo, error) {
 	return list, nil
 }
 
+func (s Source) readProcess(pid int, boot time.Time) (ProcInfo, error) {
+	dir := s.pidDir(pid)
+
+	ifo, err := readProcInfo(dir)
+	if err != nil {
+		return ifo, err
+	}
+	ifo.When = time.Now()
+	if ifo.Args, err = readCmdline(dir); err != nil {
+		return ifo, err
+	}
+	if err = readProcStat(dir, boot, &ifo); err != nil {
+		return ifo, err
+	}
+	if err = readProcStatm(dir, &ifo); err != nil {
+		return ifo, err
+	}
+	if ifo.Cgroup, ifo.Container, err = readProcCgroup(dir); err != nil {
+		return ifo, err
+	}
+	ifo.Ns = readNamespaces(dir)
+	return ifo, nil
+}
+
+func readProcStat(dir string, boot time.Time, info *ProcInfo) error {
+	buf, err := os.ReadFile(filepath.Join(dir, procStat))
+	if err != nil {
+		return err
+	}
+	// the command name is enclosed in parenthesis and can contain spaces and
+	// parenthesis itself. Fields are counted after the last closing one.
+	ix := bytes.LastIndexByte(buf, ')')
+	if ix < 0 {
+		return fmt.Errorf("%s: malformed stat file", dir)
+	}
+	fields := strings.Fields(string(buf[ix+1:]))
+	if len(fields) < statFields {
+		return fmt.Errorf("%s: not enough fields in stat file", dir)
+	}
+	var (
+		values = make([]int64, statFields)
+		ticks  = func(n int64) time.Duration {
+			return time.Duration(n) * (time.Second / clockTicks)
+		}
+	)
+	for i := 1; i < len(values); i++ {
+		values[i], err = strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid value %q in stat file", dir, fields[i])
+		}
+	}
+	info.PPid = int(values[1])
+	info.Pgrp = int(values[2])
+	info.Session = int(values[3])
+	info.Tty = int(values[4])
+	info.MinFlt = values[7]
+	info.MajFlt = values[9]
+	info.Utime = ticks(values[11])
+	info.Stime = ticks(values[12])
+	info.Priority = int(values[15])
+	info.Nice = int(values[16])
+	info.Threads = int(values[17])
+	info.Start = boot.Add(ticks(values[19]))
+
+	if len(fields) > statPolicy {
+		info.RtPriority, _ = strconv.Atoi(fields[statRtPriority])
+		policy, _ := strconv.Atoi(fields[statPolicy])
+		info.Policy = SchedPolicy(policy)
+	}
+	return nil
+}
+
+func readProcStatm(dir string, info *ProcInfo) error {
+	buf, err := os.ReadFile(filepath.Join(dir, procStatm))
+	if err != nil {
+		return err
+	}
+	var (
+		fields = strings.Fields(string(buf))
+		values = make([]int64, 3)
+		size   = int64(os.Getpagesize())
+	)
+	for i := range values {
+		values[i], err = strconv.ParseInt(slices.At(fields, i), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid value in statm file", dir)
+		}
+	}
+	info.Vsz = values[0] * size
+	info.Rss = values[1] * size
+	info.Shared = values[2] * size
+	return nil
+}
+
+func readComm(dir string) (string, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, procComm))
+	if err != nil {
+		return "", err
+	}
+	buf = bytes.Trim(buf, "\x00")
+	buf = bytes.TrimSpace(buf)
+	return string(buf), nil
+}
+
+// readCmdline returns the arguments of the process. The arguments are
+// separated by NUL bytes and are returned as is.
 func readCmdline(dir string) ([]string, error) {
-	str, err := os.ReadFile(filepath.Join(dir, procCmdline))
+	buf, err := os.ReadFile(filepath.Join(dir, procCmdline))
 	if err != nil {
 		return nil, err
 	}
-	str = bytes.Trim(str, "\x00")
-	str = bytes.Map(func(r rune) rune {
-		if r == 0 {
-			return ' '
-		}
-		return r
-	}, str)
-	return shlex.Split(bytes.NewReader(str))
+	buf = bytes.TrimSuffix(buf, []byte{0})
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	return strings.Split(string(buf), "\x00"), nil
 }
 
 func readProcInfo(dir string) (ProcInfo, error) {
@@ -97,21 +296,23 @@ func readProcInfo(dir string) (ProcInfo, error) {
 			if !ok {
 				break
 			}
-			u, err := user.LookupId(uid)
-			if err != nil {
-				return info, err
+			info.User = uid
+			if u, err := user.LookupId(uid); err == nil {
+				info.User = u.Username
 			}
-			info.User = u.Username
 		case "gid":
 			gid, _, ok := strings.Cut(strings.TrimSpace(value), "\t")
 			if !ok {
 				break
 			}
-			g, err := user.LookupGroupId(gid)
-			if err != nil {
-				return info, err
+			info.Group = gid
+			if g, err := user.LookupGroupId(gid); err == nil {
+				info.Group = g.Name
 			}
-			info.Group = g.Name
+		case "voluntary_ctxt_switches":
+			info.VoluntaryCtxt, _ = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		case "nonvoluntary_ctxt_switches":
+			info.NonVoluntaryCtxt, _ = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
 		default:
 		}
 	}
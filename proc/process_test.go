@@ -0,0 +1,104 @@
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadProcStat(t *testing.T) {
+	var (
+		dir  = fixtures.pidDir(50)
+		boot = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		info ProcInfo
+	)
+	if err := readProcStat(dir, boot, &info); err != nil {
+		t.Fatal(err)
+	}
+	if err := readProcStatm(dir, &info); err != nil {
+		t.Fatal(err)
+	}
+	size := int64(os.Getpagesize())
+	want := ProcInfo{
+		PPid:       1,
+		Pgrp:       50,
+		Session:    50,
+		Tty:        34817,
+		MinFlt:     1200,
+		MajFlt:     3,
+		Utime:      2500 * time.Millisecond,
+		Stime:      750 * time.Millisecond,
+		Priority:   20,
+		Nice:       -5,
+		Threads:    4,
+		Start:      boot.Add(123450 * time.Millisecond),
+		Vsz:        2560 * size,
+		Rss:        128 * size,
+		Shared:     64 * size,
+		RtPriority: 10,
+		Policy:     SchedRR,
+	}
+	if !info.Start.Equal(want.Start) {
+		t.Errorf("start mismatched! want %s, got %s", want.Start, info.Start)
+	}
+	info.Start = want.Start
+	if info.PPid != want.PPid || info.Pgrp != want.Pgrp || info.Session != want.Session || info.Tty != want.Tty {
+		t.Errorf("identifiers mismatched! want %+v, got %+v", want, info)
+	}
+	if info.MinFlt != want.MinFlt || info.MajFlt != want.MajFlt || info.Utime != want.Utime || info.Stime != want.Stime {
+		t.Errorf("counters mismatched! want %+v, got %+v", want, info)
+	}
+	if info.Priority != want.Priority || info.Nice != want.Nice || info.Threads != want.Threads {
+		t.Errorf("scheduling mismatched! want %+v, got %+v", want, info)
+	}
+	if info.RtPriority != want.RtPriority || info.Policy != want.Policy {
+		t.Errorf("policy mismatched! want %d/%s, got %d/%s", want.RtPriority, want.Policy, info.RtPriority, info.Policy)
+	}
+	if info.Vsz != want.Vsz || info.Rss != want.Rss || info.Shared != want.Shared {
+		t.Errorf("memory mismatched! want %+v, got %+v", want, info)
+	}
+	if term := info.Terminal(); term != "pts/1" {
+		t.Errorf("terminal mismatched! want pts/1, got %s", term)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	data := []struct {
+		Tty  int
+		Want string
+	}{
+		{Tty: 0, Want: ""},
+		{Tty: 4<<8 | 1, Want: "tty1"},
+		{Tty: 4<<8 | 64, Want: "ttyS0"},
+		{Tty: 136<<8 | 1, Want: "pts/1"},
+		{Tty: 137<<8 | 2, Want: "pts/258"},
+		{Tty: 136<<8 | 44 | 1<<20, Want: "pts/300"},
+		{Tty: 8<<8 | 1, Want: "8:1"},
+	}
+	for _, d := range data {
+		got := ProcInfo{Tty: d.Tty}.Terminal()
+		if got != d.Want {
+			t.Errorf("%d: terminal mismatched! want %q, got %q", d.Tty, d.Want, got)
+		}
+	}
+}
+
+func TestTicks(t *testing.T) {
+	// more than 2.9 years of cpu time overflows if ticks are multiplied by
+	// time.Second before being divided by clockTicks.
+	var (
+		dir  = t.TempDir()
+		info ProcInfo
+	)
+	stat := "1 (init) S 0 1 1 0 -1 0 0 0 0 0 10000000000 0 0 0 20 0 1 0 0 0 0 0 0"
+	if err := os.WriteFile(filepath.Join(dir, procStat), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := readProcStat(dir, time.Time{}, &info); err != nil {
+		t.Fatal(err)
+	}
+	if want := 100000000 * time.Second; info.Utime != want {
+		t.Errorf("utime mismatched! want %s, got %s", want, info.Utime)
+	}
+}
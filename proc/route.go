@@ -0,0 +1,204 @@
+package proc
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/slices"
+)
+
+type RouteFlags uint32
+
+const (
+	RouteUp        RouteFlags = 0x0001
+	RouteGateway   RouteFlags = 0x0002
+	RouteHost      RouteFlags = 0x0004
+	RouteReinstate RouteFlags = 0x0008
+	RouteDynamic   RouteFlags = 0x0010
+	RouteModified  RouteFlags = 0x0020
+	RouteReject    RouteFlags = 0x0200
+	RouteDefault   RouteFlags = 0x10000
+	RouteAddrConf  RouteFlags = 0x40000
+	RouteCache     RouteFlags = 0x1000000
+)
+
+// String returns the flags in the format used by route -n.
+func (f RouteFlags) String() string {
+	var (
+		str   strings.Builder
+		flags = []struct {
+			Flag RouteFlags
+			Char byte
+		}{
+			{Flag: RouteUp, Char: 'U'},
+			{Flag: RouteGateway, Char: 'G'},
+			{Flag: RouteHost, Char: 'H'},
+			{Flag: RouteReinstate, Char: 'R'},
+			{Flag: RouteDynamic, Char: 'D'},
+			{Flag: RouteModified, Char: 'M'},
+			{Flag: RouteAddrConf, Char: 'A'},
+			{Flag: RouteCache, Char: 'C'},
+			{Flag: RouteReject, Char: '!'},
+		}
+	)
+	for _, x := range flags {
+		if f&x.Flag != 0 {
+			str.WriteByte(x.Char)
+		}
+	}
+	return str.String()
+}
+
+type RouteInfo struct {
+	Interface string
+	Mask      netip.Prefix
+	Network   netip.Addr
+	Gateway   netip.Addr
+	Source    netip.Prefix
+	Flags     RouteFlags
+	RefCnt    int
+	Use       int
+	Metric    int
+	MTU       int
+	Window    int
+	IRTT      int
+}
+
+func (r RouteInfo) Default() bool {
+	return r.Mask.Bits() == 0
+}
+
+func Routes() ([]RouteInfo, error) {
+	return system.Routes()
+}
+
+// Routes returns the IPv4 routing table.
+func (s Source) Routes() ([]RouteInfo, error) {
+	r, err := os.Open(s.path(routeFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	getInt := func(str string, base int) int {
+		if err != nil {
+			return 0
+		}
+		var n int64
+		n, err = strconv.ParseInt(str, base, 64)
+		return int(n)
+	}
+
+	var (
+		list []RouteInfo
+		scan = bufio.NewScanner(r)
+	)
+	scan.Scan()
+	for scan.Scan() {
+		var (
+			line   = strings.TrimSpace(scan.Text())
+			fields = strings.Fields(line)
+			route  RouteInfo
+			mask   netip.Addr
+		)
+		route.Interface = slices.At(fields, 0)
+		if route.Network, err = parseHexAddr(slices.At(fields, 1)); err != nil {
+			return nil, err
+		}
+		if route.Gateway, err = parseHexAddr(slices.At(fields, 2)); err != nil {
+			return nil, err
+		}
+		if mask, err = parseHexAddr(slices.At(fields, 7)); err != nil {
+			return nil, err
+		}
+		route.Mask = netip.PrefixFrom(route.Network, maskBits(mask))
+
+		route.Flags = RouteFlags(getInt(slices.At(fields, 3), 16))
+		route.RefCnt = getInt(slices.At(fields, 4), 10)
+		route.Use = getInt(slices.At(fields, 5), 10)
+		route.Metric = getInt(slices.At(fields, 6), 10)
+		route.MTU = getInt(slices.At(fields, 8), 10)
+		route.Window = getInt(slices.At(fields, 9), 10)
+		route.IRTT = getInt(slices.At(fields, 10), 10)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, route)
+	}
+	return list, scan.Err()
+}
+
+func Routes6() ([]RouteInfo, error) {
+	return system.Routes6()
+}
+
+// Routes6 returns the IPv6 routing table.
+func (s Source) Routes6() ([]RouteInfo, error) {
+	r, err := os.Open(s.path(route6File))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	getAddr := func(str string) netip.Addr {
+		if err != nil {
+			return netip.Addr{}
+		}
+		var buf []byte
+		if buf, err = hex.DecodeString(str); err != nil {
+			return netip.Addr{}
+		}
+		ip, ok := netip.AddrFromSlice(buf)
+		if !ok {
+			err = fmt.Errorf("%s: invalid address", str)
+		}
+		return ip
+	}
+	getInt := func(str string) int {
+		if err != nil {
+			return 0
+		}
+		var n uint64
+		n, err = strconv.ParseUint(str, 16, 32)
+		return int(n)
+	}
+
+	var (
+		list []RouteInfo
+		scan = bufio.NewScanner(r)
+	)
+	for scan.Scan() {
+		var (
+			fields = strings.Fields(scan.Text())
+			route  RouteInfo
+		)
+		route.Network = getAddr(slices.At(fields, 0))
+		route.Mask = netip.PrefixFrom(route.Network, getInt(slices.At(fields, 1)))
+		route.Source = netip.PrefixFrom(getAddr(slices.At(fields, 2)), getInt(slices.At(fields, 3)))
+		route.Gateway = getAddr(slices.At(fields, 4))
+		route.Metric = getInt(slices.At(fields, 5))
+		route.RefCnt = getInt(slices.At(fields, 6))
+		route.Use = getInt(slices.At(fields, 7))
+		route.Flags = RouteFlags(getInt(slices.At(fields, 8)))
+		route.Interface = slices.At(fields, 9)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, route)
+	}
+	return list, scan.Err()
+}
+
+func maskBits(mask netip.Addr) int {
+	var n int
+	for _, b := range mask.AsSlice() {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
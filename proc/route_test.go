@@ -0,0 +1,91 @@
+package proc
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestRoutes(t *testing.T) {
+	list, err := fixtures.Routes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RouteInfo{
+		{
+			Interface: "eth0",
+			Mask:      netip.MustParsePrefix("0.0.0.0/0"),
+			Network:   netip.MustParseAddr("0.0.0.0"),
+			Gateway:   netip.MustParseAddr("192.168.2.1"),
+			Flags:     RouteUp | RouteGateway,
+			Metric:    100,
+		},
+		{
+			Interface: "eth0",
+			Mask:      netip.MustParsePrefix("192.168.2.0/24"),
+			Network:   netip.MustParseAddr("192.168.2.0"),
+			Gateway:   netip.MustParseAddr("0.0.0.0"),
+			Flags:     RouteUp,
+			Metric:    100,
+		},
+		{
+			Interface: "docker0",
+			Mask:      netip.MustParsePrefix("172.17.0.0/16"),
+			Network:   netip.MustParseAddr("172.17.0.0"),
+			Gateway:   netip.MustParseAddr("0.0.0.0"),
+			Flags:     RouteUp,
+			RefCnt:    1,
+			Use:       5,
+			MTU:       1500,
+		},
+	}
+	checkRoutes(t, list, want)
+	if !list[0].Default() || list[1].Default() {
+		t.Errorf("default route not detected")
+	}
+	if str := list[0].Flags.String(); str != "UG" {
+		t.Errorf("flags mismatched! want UG, got %s", str)
+	}
+}
+
+func TestRoutes6(t *testing.T) {
+	list, err := fixtures.Routes6()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RouteInfo{
+		{
+			Interface: "eth0",
+			Mask:      netip.MustParsePrefix("2001:db8::/32"),
+			Network:   netip.MustParseAddr("2001:db8::"),
+			Source:    netip.MustParsePrefix("::/0"),
+			Gateway:   netip.MustParseAddr("::"),
+			Flags:     RouteUp,
+			Metric:    256,
+			RefCnt:    1,
+		},
+		{
+			Interface: "eth0",
+			Mask:      netip.MustParsePrefix("::/0"),
+			Network:   netip.MustParseAddr("::"),
+			Source:    netip.MustParsePrefix("::/0"),
+			Gateway:   netip.MustParseAddr("fe80::1"),
+			Flags:     RouteUp | RouteGateway,
+			Metric:    1024,
+			RefCnt:    2,
+			Use:       16,
+		},
+	}
+	checkRoutes(t, list, want)
+}
+
+func checkRoutes(t *testing.T, list, want []RouteInfo) {
+	t.Helper()
+	if len(list) != len(want) {
+		t.Fatalf("routes mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Errorf("%s: route mismatched! want %+v, got %+v", want[i].Mask, want[i], list[i])
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package proc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MemUsage is the memory used by a process as reported by smaps. Values are
+// in bytes.
+type MemUsage struct {
+	Rss          int64
+	Pss          int64
+	SharedClean  int64
+	SharedDirty  int64
+	PrivateClean int64
+	PrivateDirty int64
+	Swap         int64
+	SwapPss      int64
+}
+
+// Uss returns the unique set size of the process: the memory that would be
+// released if the process exits.
+func (m MemUsage) Uss() int64 {
+	return m.PrivateClean + m.PrivateDirty
+}
+
+func MemoryUsage(pid int) (MemUsage, error) {
+	return system.MemoryUsage(pid)
+}
+
+// MemoryUsage reads /proc/[pid]/smaps_rollup and falls back on smaps on
+// kernels that do not provide it.
+func (s Source) MemoryUsage(pid int) (MemUsage, error) {
+	dir := s.pidDir(pid)
+	usage, err := readSmaps(filepath.Join(dir, procSmapsRollup))
+	if errors.Is(err, fs.ErrNotExist) {
+		usage, err = readSmaps(filepath.Join(dir, procSmaps))
+	}
+	return usage, err
+}
+
+// readSmaps sums the values of all the mappings of a smaps file. The rollup
+// file has a single mapping.
+func readSmaps(file string) (MemUsage, error) {
+	var usage MemUsage
+
+	r, err := os.Open(file)
+	if err != nil {
+		return usage, err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		field, value, ok := strings.Cut(scan.Text(), ":")
+		if !ok {
+			continue
+		}
+		var ptr *int64
+		switch field {
+		case "Rss":
+			ptr = &usage.Rss
+		case "Pss":
+			ptr = &usage.Pss
+		case "Shared_Clean":
+			ptr = &usage.SharedClean
+		case "Shared_Dirty":
+			ptr = &usage.SharedDirty
+		case "Private_Clean":
+			ptr = &usage.PrivateClean
+		case "Private_Dirty":
+			ptr = &usage.PrivateDirty
+		case "Swap":
+			ptr = &usage.Swap
+		case "SwapPss":
+			ptr = &usage.SwapPss
+		default:
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "kB"))
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return usage, fmt.Errorf("%s: invalid value for %s", file, field)
+		}
+		*ptr += n << 10
+	}
+	return usage, scan.Err()
+}
+
+type MapInfo struct {
+	Start  uint64
+	End    uint64
+	Perms  string
+	Offset uint64
+	Dev    string
+	Inode  uint64
+	Path   string
+}
+
+func (m MapInfo) Size() int64 {
+	return int64(m.End - m.Start)
+}
+
+// Anonymous reports whether the mapping is not backed by a file.
+func (m MapInfo) Anonymous() bool {
+	return m.Inode == 0
+}
+
+func Maps(pid int) ([]MapInfo, error) {
+	return system.Maps(pid)
+}
+
+// Maps returns the memory mappings of a process from /proc/[pid]/maps.
+func (s Source) Maps(pid int) ([]MapInfo, error) {
+	file := filepath.Join(s.pidDir(pid), procMaps)
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		scan = bufio.NewScanner(r)
+		list []MapInfo
+	)
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("%s: not enough fields", file)
+		}
+		var (
+			m              MapInfo
+			errs           [4]error
+			start, end, ok = strings.Cut(fields[0], "-")
+		)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid address range %q", file, fields[0])
+		}
+		m.Start, errs[0] = strconv.ParseUint(start, 16, 64)
+		m.End, errs[1] = strconv.ParseUint(end, 16, 64)
+		m.Perms = fields[1]
+		m.Offset, errs[2] = strconv.ParseUint(fields[2], 16, 64)
+		m.Dev = fields[3]
+		m.Inode, errs[3] = strconv.ParseUint(fields[4], 10, 64)
+		if err := errors.Join(errs[:]...); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if len(fields) > 5 {
+			m.Path = strings.Join(fields[5:], " ")
+		}
+		list = append(list, m)
+	}
+	return list, scan.Err()
+}
+
+// MapSummary is the total size of the mappings sharing the same path.
+type MapSummary struct {
+	Path  string
+	Count int
+	Size  int64
+}
+
+// SummarizeMaps groups the mappings by their path. Anonymous mappings
+// without name are grouped under [anon]. The result is sorted by size in
+// decreasing order.
+func SummarizeMaps(list []MapInfo) []MapSummary {
+	var (
+		index = make(map[string]int)
+		res   []MapSummary
+	)
+	for _, m := range list {
+		path := m.Path
+		if path == "" {
+			path = "[anon]"
+		}
+		ix, ok := index[path]
+		if !ok {
+			ix = len(res)
+			index[path] = ix
+			res = append(res, MapSummary{Path: path})
+		}
+		res[ix].Count++
+		res[ix].Size += m.Size()
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Size > res[j].Size
+	})
+	return res
+}
@@ -0,0 +1,82 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestMemoryUsage(t *testing.T) {
+	data := []struct {
+		Pid  int
+		Want MemUsage
+		Uss  int64
+	}{
+		{
+			Pid: 42,
+			Want: MemUsage{
+				Rss:          1412 << 10,
+				Pss:          478 << 10,
+				SharedClean:  1268 << 10,
+				PrivateClean: 40 << 10,
+				PrivateDirty: 104 << 10,
+				Swap:         12 << 10,
+				SwapPss:      6 << 10,
+			},
+			Uss: 144 << 10,
+		},
+		{
+			// no smaps_rollup: values of each mapping are summed
+			Pid: 43,
+			Want: MemUsage{
+				Rss:          24 << 10,
+				Pss:          20 << 10,
+				SharedClean:  8 << 10,
+				PrivateClean: 4 << 10,
+				PrivateDirty: 12 << 10,
+				Swap:         8 << 10,
+				SwapPss:      8 << 10,
+			},
+			Uss: 16 << 10,
+		},
+	}
+	for _, d := range data {
+		got, err := fixtures.MemoryUsage(d.Pid)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", d.Pid, err)
+			continue
+		}
+		if got != d.Want {
+			t.Errorf("%d: usage mismatched! want %+v, got %+v", d.Pid, d.Want, got)
+		}
+		if got.Uss() != d.Uss {
+			t.Errorf("%d: uss mismatched! want %d, got %d", d.Pid, d.Uss, got.Uss())
+		}
+	}
+}
+
+func TestMaps(t *testing.T) {
+	list, err := fixtures.Maps(43)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 5 {
+		t.Fatalf("mappings mismatched! want %d, got %d", 5, len(list))
+	}
+	if p := list[4].Path; p != "/tmp/my file" {
+		t.Errorf("path mismatched! want %q, got %q", "/tmp/my file", p)
+	}
+	want := []MapSummary{
+		{Path: "[heap]", Count: 1, Size: 0x21000},
+		{Path: "/usr/bin/head", Count: 2, Size: 0x8000},
+		{Path: "/tmp/my file", Count: 1, Size: 0x2000},
+		{Path: "[anon]", Count: 1, Size: 0x1000},
+	}
+	got := SummarizeMaps(list)
+	if len(got) != len(want) {
+		t.Fatalf("summaries mismatched! want %d, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s: summary mismatched! want %+v, got %+v", want[i].Path, want[i], got[i])
+		}
+	}
+}
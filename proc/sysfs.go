@@ -0,0 +1,240 @@
+package proc
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SensorKind int
+
+const (
+	SensorTemp SensorKind = iota
+	SensorFan
+	SensorVoltage
+	SensorCurrent
+	SensorPower
+)
+
+func (s SensorKind) String() string {
+	switch s {
+	default:
+		return "unknown"
+	case SensorTemp:
+		return "temperature"
+	case SensorFan:
+		return "fan"
+	case SensorVoltage:
+		return "voltage"
+	case SensorCurrent:
+		return "current"
+	case SensorPower:
+		return "power"
+	}
+}
+
+// Unit returns the unit of the values of the sensor after conversion.
+func (s SensorKind) Unit() string {
+	switch s {
+	default:
+		return ""
+	case SensorTemp:
+		return "°C"
+	case SensorFan:
+		return "RPM"
+	case SensorVoltage:
+		return "V"
+	case SensorCurrent:
+		return "A"
+	case SensorPower:
+		return "W"
+	}
+}
+
+// SensorInfo describes one sensor of a chip. Values are converted to the unit
+// of the sensor. Thresholds not exposed by the driver are set to NaN.
+type SensorInfo struct {
+	Chip   string
+	Device string
+	Kind   SensorKind
+	Label  string
+	Input  float64
+	Min    float64
+	Max    float64
+	Crit   float64
+}
+
+var sensorPrefixes = []struct {
+	Prefix string
+	Kind   SensorKind
+	Scale  float64
+}{
+	{Prefix: "temp", Kind: SensorTemp, Scale: 1000},
+	{Prefix: "fan", Kind: SensorFan, Scale: 1},
+	{Prefix: "in", Kind: SensorVoltage, Scale: 1000},
+	{Prefix: "curr", Kind: SensorCurrent, Scale: 1000},
+	{Prefix: "power", Kind: SensorPower, Scale: 1000000},
+}
+
+func Sensors() ([]SensorInfo, error) {
+	return system.Sensors()
+}
+
+// Sensors returns the sensors of the thermal zones and of the hwmon chips.
+func (s Source) Sensors() ([]SensorInfo, error) {
+	list, err := s.ThermalZones()
+	if err != nil {
+		return nil, err
+	}
+	rest, err := s.Hwmon()
+	if err != nil {
+		return nil, err
+	}
+	return append(list, rest...), nil
+}
+
+func ThermalZones() ([]SensorInfo, error) {
+	return system.ThermalZones()
+}
+
+func (s Source) ThermalZones() ([]SensorInfo, error) {
+	dirs, err := filepath.Glob(s.path(thermalDir, "thermal_zone*"))
+	if err != nil {
+		return nil, err
+	}
+	var list []SensorInfo
+	for _, dir := range dirs {
+		temp, err := readSysValue(dir, "temp", 1000)
+		if err != nil {
+			continue
+		}
+		sensor := SensorInfo{
+			Device: filepath.Base(dir),
+			Kind:   SensorTemp,
+			Input:  temp,
+			Min:    math.NaN(),
+			Max:    math.NaN(),
+			Crit:   math.NaN(),
+		}
+		sensor.Chip = readSysString(dir, "type")
+		sensor.Label = sensor.Chip
+		trips, _ := filepath.Glob(filepath.Join(dir, "trip_point_*_type"))
+		for _, t := range trips {
+			var (
+				name  = strings.TrimSuffix(filepath.Base(t), "_type")
+				value = readSysValueOr(dir, name+"_temp", 1000)
+			)
+			switch readSysString(dir, filepath.Base(t)) {
+			case "critical":
+				sensor.Crit = value
+			case "hot":
+				sensor.Max = value
+			default:
+			}
+		}
+		list = append(list, sensor)
+	}
+	return list, nil
+}
+
+func Hwmon() ([]SensorInfo, error) {
+	return system.Hwmon()
+}
+
+func (s Source) Hwmon() ([]SensorInfo, error) {
+	dirs, err := filepath.Glob(s.path(hwmonDir, "hwmon*"))
+	if err != nil {
+		return nil, err
+	}
+	var list []SensorInfo
+	for _, dir := range dirs {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		var (
+			chip   = readSysString(dir, "name")
+			device = filepath.Base(dir)
+			names  []string
+		)
+		for _, f := range files {
+			if name, ok := strings.CutSuffix(f.Name(), "_input"); ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sensor, ok := readHwmonSensor(dir, name)
+			if !ok {
+				continue
+			}
+			sensor.Chip = chip
+			sensor.Device = device
+			list = append(list, sensor)
+		}
+	}
+	return list, nil
+}
+
+func readHwmonSensor(dir, name string) (SensorInfo, bool) {
+	var sensor SensorInfo
+	for _, p := range sensorPrefixes {
+		if !strings.HasPrefix(name, p.Prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, p.Prefix)); err != nil {
+			continue
+		}
+		input, err := readSysValue(dir, name+"_input", p.Scale)
+		if err != nil {
+			return sensor, false
+		}
+		sensor.Kind = p.Kind
+		sensor.Input = input
+		sensor.Min = readSysValueOr(dir, name+"_min", p.Scale)
+		sensor.Max = readSysValueOr(dir, name+"_max", p.Scale)
+		sensor.Crit = readSysValueOr(dir, name+"_crit", p.Scale)
+		if sensor.Label = readSysString(dir, name+"_label"); sensor.Label == "" {
+			sensor.Label = name
+		}
+		return sensor, true
+	}
+	return sensor, false
+}
+
+func readSysString(dir, file string) string {
+	buf, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(buf))
+}
+
+func readSysValue(dir, file string, scale float64) (float64, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(string(bytes.TrimSpace(buf)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(n) / scale, nil
+}
+
+func readSysValueOr(dir, file string, scale float64) float64 {
+	n, err := readSysValue(dir, file, scale)
+	if err != nil {
+		return math.NaN()
+	}
+	return n
+}
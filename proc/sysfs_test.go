@@ -0,0 +1,37 @@
+package proc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHwmon(t *testing.T) {
+	list, err := fixtures.Hwmon()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SensorInfo{
+		{Kind: SensorFan, Label: "fan1", Input: 1200, Min: math.NaN(), Max: math.NaN(), Crit: math.NaN()},
+		{Kind: SensorVoltage, Label: "in0", Input: 1.212, Min: math.NaN(), Max: math.NaN(), Crit: math.NaN()},
+		{Kind: SensorPower, Label: "power1", Input: 5, Min: math.NaN(), Max: math.NaN(), Crit: math.NaN()},
+		{Kind: SensorTemp, Label: "Package id 0", Input: 45.5, Min: math.NaN(), Max: 80, Crit: 100},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("sensors mismatched! want %d, got %d", len(want), len(list))
+	}
+	same := func(x, y float64) bool {
+		return (math.IsNaN(x) && math.IsNaN(y)) || x == y
+	}
+	for i, w := range want {
+		got := list[i]
+		if got.Chip != "coretemp" || got.Device != "hwmon0" {
+			t.Errorf("%s: chip mismatched! got %s-%s", w.Label, got.Chip, got.Device)
+		}
+		if got.Kind != w.Kind || got.Label != w.Label {
+			t.Errorf("%s: sensor mismatched! want %s/%s, got %s/%s", w.Label, w.Kind, w.Label, got.Kind, got.Label)
+		}
+		if !same(got.Input, w.Input) || !same(got.Min, w.Min) || !same(got.Max, w.Max) || !same(got.Crit, w.Crit) {
+			t.Errorf("%s: values mismatched! want %+v, got %+v", w.Label, w, got)
+		}
+	}
+}
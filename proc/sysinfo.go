@@ -0,0 +1,202 @@
+package proc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type SystemInfo struct {
+	Version     string
+	Release     string
+	Hostname    string
+	Domainname  string
+	MachineId   string
+	OS          map[string]string
+	Container   string
+	ContainerId string
+	Virtual     string
+}
+
+// PrettyName returns the name of the distribution suitable for display.
+func (s SystemInfo) PrettyName() string {
+	if name, ok := s.OS["PRETTY_NAME"]; ok {
+		return name
+	}
+	return s.OS["NAME"]
+}
+
+func Identity() (SystemInfo, error) {
+	return system.Identity()
+}
+
+// Identity returns the information identifying the system: kernel, host
+// name, distribution and the container or the hypervisor it runs into.
+func (s Source) Identity() (SystemInfo, error) {
+	var (
+		info SystemInfo
+		err  error
+	)
+	if info.Version, err = s.readString(versionFile); err != nil {
+		return info, err
+	}
+	if info.Release, err = s.readString(osreleaseFile); err != nil {
+		return info, err
+	}
+	if err = s.readHostname(&info); err != nil {
+		return info, err
+	}
+	info.MachineId, _ = s.readString(machineIdFile)
+	if info.OS, err = s.readOsRelease(); err != nil {
+		return info, err
+	}
+	info.Container, info.ContainerId = s.detectContainer()
+	info.Virtual = s.detectVirtual()
+	return info, nil
+}
+
+// readHostname reads the host and domain names. The kernel reports them for
+// the UTS namespace of the reading process, so, for a system mounted under
+// another root, the host name is read from its /etc/hostname and the domain
+// name is left empty.
+func (s Source) readHostname(info *SystemInfo) error {
+	var err error
+	if filepath.Clean(s.Root()) != "/" {
+		info.Hostname, err = s.readString(etcHostnameFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return err
+	}
+	if info.Hostname, err = s.readString(hostnameFile); err != nil {
+		return err
+	}
+	if info.Domainname, err = s.readString(domainnameFile); err != nil {
+		return err
+	}
+	if info.Domainname == "(none)" {
+		info.Domainname = ""
+	}
+	return nil
+}
+
+func (s Source) readString(file string) (string, error) {
+	buf, err := os.ReadFile(s.path(file))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(buf)), nil
+}
+
+// readOsRelease reads the os-release file. Its absence is not an error and
+// results in an empty map.
+func (s Source) readOsRelease() (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, file := range []string{osReleaseFile, osReleaseLibFile} {
+		r, err := os.Open(s.path(file))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		defer r.Close()
+
+		scan := bufio.NewScanner(r)
+		for scan.Scan() {
+			line := strings.TrimSpace(scan.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			if str, err := strconv.Unquote(value); err == nil {
+				value = str
+			} else {
+				value = strings.Trim(value, "'")
+			}
+			fields[key] = value
+		}
+		return fields, scan.Err()
+	}
+	return fields, nil
+}
+
+// detectContainer looks for the files created by the container engines, the
+// container variable set in the environment of the init process and for the
+// cgroup of the init process. It returns the name of the engine and the
+// identifier of the container if known.
+func (s Source) detectContainer() (string, string) {
+	var (
+		dir     = s.pidDir(1)
+		runtime string
+		id      string
+	)
+	if r, err := os.Open(filepath.Join(dir, procCgroup)); err == nil {
+		defer r.Close()
+		scan := bufio.NewScanner(r)
+		for scan.Scan() && id == "" {
+			parts := strings.SplitN(scan.Text(), ":", 3)
+			if len(parts) == 3 {
+				runtime, id = ContainerRuntime(parts[2])
+			}
+		}
+	}
+	env, _ := readEnviron(dir)
+	switch {
+	case env["container"] != "":
+		runtime = env["container"]
+	case runtime != "":
+	case exists(s.path(".dockerenv")):
+		runtime = "docker"
+	case exists(s.path("run", ".containerenv")):
+		runtime = "podman"
+	}
+	return runtime, id
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+// detectVirtual identifies the hypervisor from the DMI information and falls
+// back on the hypervisor flag of the cpus.
+func (s Source) detectVirtual() string {
+	var (
+		vendor, _  = s.readString(dmiVendorFile)
+		product, _ = s.readString(dmiProductFile)
+		ident      = vendor + " " + product
+	)
+	for _, vm := range []struct {
+		Pattern string
+		Name    string
+	}{
+		{Pattern: "KVM", Name: "kvm"},
+		{Pattern: "QEMU", Name: "qemu"},
+		{Pattern: "VMware", Name: "vmware"},
+		{Pattern: "VirtualBox", Name: "virtualbox"},
+		{Pattern: "innotek", Name: "virtualbox"},
+		{Pattern: "Xen", Name: "xen"},
+		{Pattern: "Amazon EC2", Name: "amazon"},
+		{Pattern: "Google Compute Engine", Name: "google"},
+		{Pattern: "Microsoft Corporation Virtual Machine", Name: "hyperv"},
+		{Pattern: "Parallels", Name: "parallels"},
+	} {
+		if strings.Contains(ident, vm.Pattern) {
+			return vm.Name
+		}
+	}
+	list, err := s.Processors()
+	if err == nil && len(list) > 0 && list[0].Has("hypervisor") {
+		return "unknown"
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package proc
+
+import (
+	"sort"
+	"time"
+)
+
+type ProcNode struct {
+	ProcInfo
+	Parent   *ProcNode
+	Children []*ProcNode
+}
+
+type ProcTotal struct {
+	Count   int
+	Threads int
+	Utime   time.Duration
+	Stime   time.Duration
+	MinFlt  int64
+	MajFlt  int64
+	Vsz     int64
+	Rss     int64
+}
+
+// Total returns the resources used by the process and all its descendants.
+func (n *ProcNode) Total() ProcTotal {
+	total := ProcTotal{
+		Count:   1,
+		Threads: n.Threads,
+		Utime:   n.Utime,
+		Stime:   n.Stime,
+		MinFlt:  n.MinFlt,
+		MajFlt:  n.MajFlt,
+		Vsz:     n.Vsz,
+		Rss:     n.Rss,
+	}
+	for _, c := range n.Children {
+		sub := c.Total()
+		total.Count += sub.Count
+		total.Threads += sub.Threads
+		total.Utime += sub.Utime
+		total.Stime += sub.Stime
+		total.MinFlt += sub.MinFlt
+		total.MajFlt += sub.MajFlt
+		total.Vsz += sub.Vsz
+		total.Rss += sub.Rss
+	}
+	return total
+}
+
+type ProcessTree struct {
+	Roots []*ProcNode
+	nodes map[int]*ProcNode
+}
+
+// NewProcessTree builds the parent/child relations of the given processes.
+// Processes whose parent is not part of the list become roots of the tree.
+func NewProcessTree(list []ProcInfo) *ProcessTree {
+	tree := ProcessTree{
+		nodes: make(map[int]*ProcNode),
+	}
+	for i := range list {
+		tree.nodes[list[i].Pid] = &ProcNode{
+			ProcInfo: list[i],
+		}
+	}
+	for _, n := range tree.nodes {
+		parent, ok := tree.nodes[n.PPid]
+		if !ok || parent == n {
+			tree.Roots = append(tree.Roots, n)
+			continue
+		}
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+	sortNodes(tree.Roots)
+	for _, n := range tree.nodes {
+		sortNodes(n.Children)
+	}
+	return &tree
+}
+
+func (t *ProcessTree) Get(pid int) (*ProcNode, bool) {
+	n, ok := t.nodes[pid]
+	return n, ok
+}
+
+// Ancestors returns the parents of the given process starting with its
+// direct parent.
+func (t *ProcessTree) Ancestors(pid int) []ProcInfo {
+	n, ok := t.nodes[pid]
+	if !ok {
+		return nil
+	}
+	var list []ProcInfo
+	for p := n.Parent; p != nil; p = p.Parent {
+		list = append(list, p.ProcInfo)
+	}
+	return list
+}
+
+// Descendants returns all the children of the given process in depth first
+// order.
+func (t *ProcessTree) Descendants(pid int) []ProcInfo {
+	n, ok := t.nodes[pid]
+	if !ok {
+		return nil
+	}
+	var list []ProcInfo
+	for _, c := range n.Children {
+		walkNode(c, 0, func(n *ProcNode, _ int) {
+			list = append(list, n.ProcInfo)
+		})
+	}
+	return list
+}
+
+// Walk calls fn for each process of the tree in depth first order with the
+// depth of the process in the tree.
+func (t *ProcessTree) Walk(fn func(*ProcNode, int)) {
+	for _, n := range t.Roots {
+		walkNode(n, 0, fn)
+	}
+}
+
+func walkNode(n *ProcNode, depth int, fn func(*ProcNode, int)) {
+	fn(n, depth)
+	for _, c := range n.Children {
+		walkNode(c, depth+1, fn)
+	}
+}
+
+func sortNodes(list []*ProcNode) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Pid < list[j].Pid
+	})
+}
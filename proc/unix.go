@@ -0,0 +1,140 @@
+package proc
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/slices"
+)
+
+type UnixType int
+
+const (
+	UnixStream    UnixType = 1
+	UnixDgram     UnixType = 2
+	UnixSeqPacket UnixType = 5
+)
+
+func (u UnixType) String() string {
+	switch u {
+	default:
+		return "unknown"
+	case UnixStream:
+		return "STREAM"
+	case UnixDgram:
+		return "DGRAM"
+	case UnixSeqPacket:
+		return "SEQPACKET"
+	}
+}
+
+// UnixState is the state of the socket as defined by socket_state in the
+// kernel (SS_FREE, SS_UNCONNECTED, ...).
+type UnixState int
+
+const (
+	UnixFree UnixState = iota
+	UnixUnconnected
+	UnixConnecting
+	UnixConnected
+	UnixDisconnecting
+)
+
+func (u UnixState) String() string {
+	switch u {
+	default:
+		return ""
+	case UnixFree:
+		return "FREE"
+	case UnixUnconnected:
+		return "UNCONNECTED"
+	case UnixConnecting:
+		return "CONNECTING"
+	case UnixConnected:
+		return "CONNECTED"
+	case UnixDisconnecting:
+		return "DISCONNECTING"
+	}
+}
+
+// acceptConn is the flag set by the kernel on listening unix sockets.
+const acceptConn = 1 << 16
+
+type UnixInfo struct {
+	Path     string
+	Abstract bool
+	Type     UnixType
+	State    UnixState
+	Listen   bool
+	RefCount int
+	Inode    uint64
+
+	Pid     int
+	Command string
+}
+
+func Unix() ([]UnixInfo, error) {
+	return system.Unix()
+}
+
+func (s Source) Unix() ([]UnixInfo, error) {
+	r, err := os.Open(s.path(unixFile))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	parse := func(str string, base int) int64 {
+		if err != nil {
+			return 0
+		}
+		var n int64
+		n, err = strconv.ParseInt(str, base, 64)
+		return n
+	}
+
+	var (
+		scan = bufio.NewScanner(r)
+		list []UnixInfo
+	)
+	scan.Scan()
+	for scan.Scan() {
+		fields := strings.Fields(scan.Text())
+		if len(fields) < 7 {
+			continue
+		}
+		var (
+			ifo   UnixInfo
+			flags = parse(slices.At(fields, 3), 16)
+		)
+		ifo.RefCount = int(parse(slices.At(fields, 1), 16))
+		ifo.Type = UnixType(parse(slices.At(fields, 4), 16))
+		ifo.State = UnixState(parse(slices.At(fields, 5), 16))
+		ifo.Inode = uint64(parse(slices.At(fields, 6), 10))
+		if err != nil {
+			return nil, err
+		}
+		ifo.Listen = flags&acceptConn != 0
+		ifo.Path = strings.Join(fields[7:], " ")
+		ifo.Abstract = strings.HasPrefix(ifo.Path, "@")
+		list = append(list, ifo)
+	}
+	return list, scan.Err()
+}
+
+func UnixOwners(list []UnixInfo) error {
+	return system.UnixOwners(list)
+}
+
+// UnixOwners sets the pid and the command of the process owning each unix
+// socket of the list.
+func (s Source) UnixOwners(list []UnixInfo) error {
+	index, err := s.Sockets()
+	if err != nil {
+		return err
+	}
+	index.SetUnixOwners(list)
+	return nil
+}
@@ -0,0 +1,44 @@
+package proc
+
+import (
+	"testing"
+)
+
+func TestUnix(t *testing.T) {
+	list, err := fixtures.Unix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []UnixInfo{
+		{Path: "/run/user/1000/bus", Type: UnixStream, State: UnixUnconnected, Listen: true, RefCount: 2, Inode: 40887},
+		{Type: UnixStream, State: UnixConnected, RefCount: 3, Inode: 53882},
+		{Path: "@/tmp/.X11-unix/X0", Abstract: true, Type: UnixDgram, State: UnixUnconnected, RefCount: 2, Inode: 1234},
+		{Path: "/tmp/my socket", Type: UnixSeqPacket, State: UnixUnconnected, Listen: true, RefCount: 2, Inode: 5678},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("sockets mismatched! want %d, got %d", len(want), len(list))
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Errorf("%d: socket mismatched! want %+v, got %+v", want[i].Inode, want[i], list[i])
+		}
+	}
+}
+
+func TestUnixState(t *testing.T) {
+	data := []struct {
+		State UnixState
+		Want  string
+	}{
+		{State: 0, Want: "FREE"},
+		{State: 1, Want: "UNCONNECTED"},
+		{State: 2, Want: "CONNECTING"},
+		{State: 3, Want: "CONNECTED"},
+		{State: 4, Want: "DISCONNECTING"},
+	}
+	for _, d := range data {
+		if got := d.State.String(); got != d.Want {
+			t.Errorf("%d: state mismatched! want %s, got %s", d.State, d.Want, got)
+		}
+	}
+}
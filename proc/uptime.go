@@ -8,7 +8,11 @@ import (
 )
 
 func Uptime() (time.Duration, error) {
-	buf, err := os.ReadFile(uptimeFile)
+	return system.Uptime()
+}
+
+func (s Source) Uptime() (time.Duration, error) {
+	buf, err := os.ReadFile(s.path(uptimeFile))
 	if err != nil {
 		return 0, err
 	}
@@ -24,12 +28,13 @@ func Uptime() (time.Duration, error) {
 }
 
 func BootTime() (time.Time, error) {
-	var (
-		sec, err = Uptime()
-		now      = time.Now()
-	)
+	return system.BootTime()
+}
+
+func (s Source) BootTime() (time.Time, error) {
+	stat, err := s.Kernel()
 	if err != nil {
-		return now, err
+		return time.Time{}, err
 	}
-	return now.Add(-sec), nil
+	return stat.BootTime, nil
 }
@@ -0,0 +1,116 @@
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VmStat holds the counters of the virtual memory subsystem. Raw contains
+// every field of /proc/vmstat.
+type VmStat struct {
+	FreePages  int64
+	DirtyPages int64
+	PageIn     int64
+	PageOut    int64
+	SwapIn     int64
+	SwapOut    int64
+	Faults     int64
+	MajFaults  int64
+	Scan       int64
+	Steal      int64
+	OomKill    int64
+
+	Raw  map[string]int64
+	When time.Time
+}
+
+func VirtualMemory() (VmStat, error) {
+	return system.VirtualMemory()
+}
+
+func (s Source) VirtualMemory() (VmStat, error) {
+	stat := VmStat{
+		Raw:  make(map[string]int64),
+		When: time.Now(),
+	}
+	r, err := os.Open(s.path(vmstatFile))
+	if err != nil {
+		return stat, err
+	}
+	defer r.Close()
+
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		field, value, ok := strings.Cut(scan.Text(), " ")
+		if !ok {
+			continue
+		}
+		val, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return stat, fmt.Errorf("%s: invalid value %q", field, value)
+		}
+		stat.Raw[field] = val
+	}
+	if err := scan.Err(); err != nil {
+		return stat, err
+	}
+	stat.FreePages = stat.Raw["nr_free_pages"]
+	stat.DirtyPages = stat.Raw["nr_dirty"]
+	stat.PageIn = stat.Raw["pgpgin"]
+	stat.PageOut = stat.Raw["pgpgout"]
+	stat.SwapIn = stat.Raw["pswpin"]
+	stat.SwapOut = stat.Raw["pswpout"]
+	stat.Faults = stat.Raw["pgfault"]
+	stat.MajFaults = stat.Raw["pgmajfault"]
+	stat.OomKill = stat.Raw["oom_kill"]
+	for _, src := range []string{"kswapd", "direct", "khugepaged", "proactive"} {
+		stat.Scan += stat.Raw["pgscan_"+src]
+		stat.Steal += stat.Raw["pgsteal_"+src]
+	}
+	return stat, nil
+}
+
+// VmActivity holds the per second rates of the virtual memory counters.
+// Paging and swapping are given in kB per second.
+type VmActivity struct {
+	PageIn    float64
+	PageOut   float64
+	SwapIn    float64
+	SwapOut   float64
+	Faults    float64
+	MajFaults float64
+	Scan      float64
+	Steal     float64
+	OomKill   float64
+}
+
+// VmRate computes the activity of the virtual memory between two samples.
+func VmRate(prev, curr VmStat) VmActivity {
+	var rate VmActivity
+
+	elapsed := curr.When.Sub(prev.When).Seconds()
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(prev, curr int64) float64 {
+		if curr < prev {
+			return 0
+		}
+		return float64(curr-prev) / elapsed
+	}
+	page := float64(os.Getpagesize()) / 1024
+	rate.PageIn = perSec(prev.PageIn, curr.PageIn)
+	rate.PageOut = perSec(prev.PageOut, curr.PageOut)
+	rate.SwapIn = perSec(prev.SwapIn, curr.SwapIn) * page
+	rate.SwapOut = perSec(prev.SwapOut, curr.SwapOut) * page
+	rate.Faults = perSec(prev.Faults, curr.Faults)
+	rate.MajFaults = perSec(prev.MajFaults, curr.MajFaults)
+	rate.Scan = perSec(prev.Scan, curr.Scan)
+	rate.Steal = perSec(prev.Steal, curr.Steal)
+	rate.OomKill = perSec(prev.OomKill, curr.OomKill)
+	return rate
+}
@@ -9,8 +9,6 @@ import (
 	"net/netip"
 	"os"
 	"os/user"
-	"path/filepath"
-	"strconv"
 	"time"
 )
 
@@ -28,6 +26,8 @@ type Who struct {
 	Session int
 	When    time.Time
 	Addr    netip.Addr
+
+	src Source
 }
 
 func (w Who) Regular() bool {
@@ -39,25 +39,24 @@ func (w Who) Regular() bool {
 }
 
 func (w Who) Command() string {
-	var (
-		pid      = strconv.Itoa(w.Pid)
-		comm     = filepath.Join(proc, pid, "comm")
-		buf, err = os.ReadFile(comm)
-	)
-	if err != nil {
-		return ""
-	}
-	buf = bytes.Trim(buf, "\x00")
-	buf = bytes.TrimSpace(buf)
-	return string(buf)
+	comm, _ := readComm(w.src.pidDir(w.Pid))
+	return comm
 }
 
 func Current() ([]Who, error) {
-	return readWho(utmpFile)
+	return system.Current()
 }
 
 func All() ([]Who, error) {
-	return readWho(wtmpFile)
+	return system.All()
+}
+
+func (s Source) Current() ([]Who, error) {
+	return s.readWho(utmpFile)
+}
+
+func (s Source) All() ([]Who, error) {
+	return s.readWho(wtmpFile)
 }
 
 const (
@@ -68,8 +67,8 @@ const (
 	addrSize   = 16
 )
 
-func readWho(file string) ([]Who, error) {
-	r, err := os.Open(file)
+func (s Source) readWho(file string) ([]Who, error) {
+	r, err := os.Open(s.path(file))
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +93,7 @@ func readWho(file string) ([]Who, error) {
 		if err != nil {
 			return nil, err
 		}
+		who.src = s
 		list = append(list, who)
 	}
 	return list, nil